@@ -1,24 +1,41 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
-	"sync"
+	"strings"
 	"time"
 )
 
 // Client is the Fiken API HTTP client with auth, rate limiting, and pagination.
+//
+// Every method has a Context variant (GetContext, PostContext, ...) that
+// cancels both the request and the wait for the rate limiter when ctx is
+// done. The methods without a ctx argument use the client's context, see
+// WithContext.
 type Client struct {
 	token      string
 	httpClient *http.Client
 	baseURL    string
+	ctx        context.Context
+	retry      RetryPolicy
 
-	// Rate limiting: max 4 req/sec, 1 concurrent
-	mu       sync.Mutex
+	// Shared by the copies made by WithContext.
+	limiter *rateLimiter
+}
+
+// rateLimiter enforces Fiken's limits of one concurrent request and at
+// most 4 requests per second. It is a one-slot semaphore rather than a
+// mutex so that waiting for it can be cancelled.
+type rateLimiter struct {
+	sem      chan struct{}
 	lastReq  time.Time
 	minDelay time.Duration
 }
@@ -30,9 +47,78 @@ func NewClient(token string) *Client {
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		baseURL:  BaseURL,
-		minDelay: 250 * time.Millisecond, // 4 req/sec
+		baseURL: BaseURL,
+		retry:   DefaultRetryPolicy,
+		limiter: &rateLimiter{
+			sem:      make(chan struct{}, 1),
+			minDelay: 250 * time.Millisecond, // 4 req/sec
+		},
+	}
+}
+
+// WithContext returns a copy of c whose methods without a ctx argument use
+// ctx. The copy shares c's rate limiter.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("api: nil context")
+	}
+	c2 := *c
+	c2.ctx = ctx
+	return &c2
+}
+
+// WithBaseURL returns a copy of c that sends its requests to baseURL
+// instead of BaseURL, for example a test server. The copy shares c's rate
+// limiter.
+func (c *Client) WithBaseURL(baseURL string) *Client {
+	c2 := *c
+	c2.baseURL = strings.TrimSuffix(baseURL, "/")
+	return &c2
+}
+
+// WithRetryPolicy returns a copy of c that retries failed requests
+// according to p. The copy shares c's rate limiter.
+func (c *Client) WithRetryPolicy(p RetryPolicy) *Client {
+	c2 := *c
+	c2.retry = p
+	return &c2
+}
+
+// context returns the client's context, or context.Background if none
+// was set with WithContext.
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// acquire waits for the request slot and for the minimum delay since the
+// previous request, or until ctx is done.
+func (l *rateLimiter) acquire(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	if wait := l.minDelay - time.Since(l.lastReq); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			<-l.sem
+			return ctx.Err()
+		}
 	}
+	return nil
+}
+
+// release frees the request slot.
+func (l *rateLimiter) release() {
+	l.lastReq = time.Now()
+	<-l.sem
 }
 
 // PaginationInfo holds pagination metadata from response headers.
@@ -48,6 +134,9 @@ type APIError struct {
 	StatusCode int
 	Status     string
 	Body       string
+
+	// RetryAfter is the delay asked for by a Retry-After header, if any.
+	RetryAfter time.Duration
 }
 
 func (e *APIError) Error() string {
@@ -57,26 +146,59 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("fiken API error %d: %s", e.StatusCode, e.Status)
 }
 
-// doRequest performs a rate-limited HTTP request.
-// The mutex is held through the entire request to enforce Fiken's
-// single-concurrent-request requirement.
+// doRequest performs a rate-limited HTTP request under the request's
+// context, retrying transient errors according to the client's retry
+// policy.
 func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	// The token is only ever sent to the API itself, not to download or
+	// Location URLs on other hosts.
+	if c.isAPIURL(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
+	if req.Body != nil && req.Header.Get("Content-Type") == "" &&
+		(req.Method == http.MethodPost || req.Method == http.MethodPut || req.Method == http.MethodPatch) {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
-	elapsed := time.Since(c.lastReq)
-	if elapsed < c.minDelay {
-		time.Sleep(c.minDelay - elapsed)
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+		if err == nil {
+			return resp, nil
+		}
+
+		wait, ok := c.retry.retryWait(req, err, attempt)
+		if !ok {
+			return nil, err
+		}
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(req, err, attempt+1, wait)
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("rewinding request body: %w", err)
+			}
+			req.Body = body
+		}
 	}
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Accept", "application/json")
-	if req.Method == http.MethodPost || req.Method == http.MethodPut {
-		req.Header.Set("Content-Type", "application/json")
+// send performs a single attempt of a request. The request slot is held
+// through the entire request to enforce Fiken's single-concurrent-request
+// requirement, but not while waiting to retry.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if err := c.limiter.acquire(req.Context()); err != nil {
+		return nil, err
 	}
+	defer c.limiter.release()
 
 	resp, err := c.httpClient.Do(req)
-	c.lastReq = time.Now()
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -88,6 +210,7 @@ func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 			StatusCode: resp.StatusCode,
 			Status:     resp.Status,
 			Body:       string(body),
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
 		}
 	}
 
@@ -96,17 +219,27 @@ func (c *Client) doRequest(req *http.Request) (*http.Response, error) {
 
 // Get performs a GET request to the given path and decodes the response.
 func (c *Client) Get(path string, result interface{}) (*PaginationInfo, error) {
-	return c.GetWithParams(path, nil, result)
+	return c.GetWithParamsContext(c.context(), path, nil, result)
+}
+
+// GetContext is like Get but uses ctx for the request.
+func (c *Client) GetContext(ctx context.Context, path string, result interface{}) (*PaginationInfo, error) {
+	return c.GetWithParamsContext(ctx, path, nil, result)
 }
 
 // GetWithParams performs a GET request with query parameters.
 func (c *Client) GetWithParams(path string, params url.Values, result interface{}) (*PaginationInfo, error) {
+	return c.GetWithParamsContext(c.context(), path, params, result)
+}
+
+// GetWithParamsContext is like GetWithParams but uses ctx for the request.
+func (c *Client) GetWithParamsContext(ctx context.Context, path string, params url.Values, result interface{}) (*PaginationInfo, error) {
 	u := c.baseURL + path
 	if params != nil {
 		u += "?" + params.Encode()
 	}
 
-	req, err := http.NewRequest(http.MethodGet, u, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("creating request: %w", err)
 	}
@@ -132,25 +265,245 @@ func (c *Client) GetWithParams(path string, params url.Values, result interface{
 	return pagination, nil
 }
 
-// Post performs a POST request with a JSON body.
-func (c *Client) Post(path string, body interface{}, result interface{}) error {
-	bodyBytes, err := json.Marshal(body)
+// GetAllPages fetches all pages for a paginated endpoint.
+// The fetchPage function should perform the actual request and return results + whether there are more pages.
+//
+// Deprecated: Use List, which performs the requests itself.
+func (c *Client) GetAllPages(path string, pageSize int, fetchPage func(page int) (int, error)) error {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	page := 0
+	for {
+		pageCount, err := fetchPage(page)
+		if err != nil {
+			return err
+		}
+		page++
+		if page >= pageCount {
+			break
+		}
+	}
+	return nil
+}
+
+// Created describes the resource created by a POST. Fiken answers creates
+// with 201 Created and a Location header pointing to the new resource
+// rather than a response body.
+type Created struct {
+	// Location is the absolute URL of the Location header, or nil if the
+	// response had none (as for actions such as sending an invoice).
+	Location *url.URL
+	// ID is the trailing numeric ID of Location, or 0 if there is none.
+	ID int64
+}
+
+// Post performs a POST request with a JSON body (nil for none) and returns
+// the Location and ID of the created resource.
+//
+// If result is not nil, the created object is decoded into it: from the
+// response body if there is one, or else by following Location with a GET.
+//
+// Like every request method, Post returns an *APIError when Fiken answers
+// with an error status.
+func (c *Client) Post(path string, body interface{}, result interface{}) (*Created, error) {
+	return c.PostContext(c.context(), path, body, result)
+}
+
+// PostContext is like Post but uses ctx for the request.
+func (c *Client) PostContext(ctx context.Context, path string, body interface{}, result interface{}) (*Created, error) {
+	resp, err := c.request(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	created := &Created{}
+	if location := resp.Header.Get("Location"); location != "" {
+		u, err := resp.Request.URL.Parse(location)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Location header: %w", err)
+		}
+		created.Location = u
+		created.ID = idFromPath(u.Path)
+	}
+
+	if result == nil {
+		return created, nil
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if len(bytes.TrimSpace(respBody)) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return nil, fmt.Errorf("decoding response: %w", err)
+		}
+		return created, nil
+	}
+	if created.Location == nil {
+		return nil, fmt.Errorf("response has neither a body nor a Location header")
+	}
+	if err := c.follow(ctx, created.Location, result); err != nil {
+		return nil, fmt.Errorf("fetching created resource: %w", err)
+	}
+	return created, nil
+}
+
+// follow fetches the resource at an absolute URL returned by Fiken and
+// decodes it into result. It refuses URLs outside the API so that the
+// token is never sent elsewhere.
+func (c *Client) follow(ctx context.Context, u *url.URL, result interface{}) error {
+	if !c.isAPIURL(u) {
+		return fmt.Errorf("location %s is outside the API", u)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("decoding response: %w", err)
+	}
+	return nil
+}
+
+// PostMultipart performs a multipart/form-data POST request with the given
+// form fields and a single file part named "file", as used for uploading
+// attachments and inbox documents.
+func (c *Client) PostMultipart(path string, fields map[string]string, filename string, file io.Reader) error {
+	return c.PostMultipartContext(c.context(), path, fields, filename, file)
+}
+
+// PostMultipartContext is like PostMultipart but uses ctx for the request.
+func (c *Client) PostMultipartContext(ctx context.Context, path string, fields map[string]string, filename string, file io.Reader) error {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for name, value := range fields {
+		if err := mw.WriteField(name, value); err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+	}
+	part, err := mw.CreateFormFile("file", filename)
 	if err != nil {
 		return fmt.Errorf("encoding request: %w", err)
 	}
+	if _, err := io.Copy(part, file); err != nil {
+		return fmt.Errorf("reading %s: %w", filename, err)
+	}
+	if err := mw.Close(); err != nil {
+		return fmt.Errorf("encoding request: %w", err)
+	}
 
 	u := c.baseURL + path
-	req, err := http.NewRequest(http.MethodPost, u, io.NopCloser(
-		io.NewSectionReader(newBytesReaderAt(bodyBytes), 0, int64(len(bodyBytes))),
-	))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, &buf)
 	if err != nil {
 		return fmt.Errorf("creating request: %w", err)
 	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
 
 	resp, err := c.doRequest(req)
 	if err != nil {
 		return err
 	}
+	resp.Body.Close()
+	return nil
+}
+
+// PostForID performs a POST request to a create endpoint and returns the ID
+// of the new resource, failing if the response has no Location with an ID.
+// A nil body sends the request without a body.
+func (c *Client) PostForID(path string, body interface{}) (int64, error) {
+	return c.PostForIDContext(c.context(), path, body)
+}
+
+// PostForIDContext is like PostForID but uses ctx for the request.
+func (c *Client) PostForIDContext(ctx context.Context, path string, body interface{}) (int64, error) {
+	created, err := c.PostContext(ctx, path, body, nil)
+	if err != nil {
+		return 0, err
+	}
+	if created.Location == nil {
+		return 0, fmt.Errorf("response has no Location header")
+	}
+	if created.ID == 0 {
+		return 0, fmt.Errorf("no resource ID in Location header %q", created.Location)
+	}
+	return created.ID, nil
+}
+
+// Put performs a PUT request with a JSON body, replacing the resource at
+// path, and decodes the response into result, unless result is nil.
+func (c *Client) Put(path string, body interface{}, result interface{}) error {
+	return c.PutContext(c.context(), path, body, result)
+}
+
+// PutContext is like Put but uses ctx for the request.
+func (c *Client) PutContext(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.sendJSON(ctx, http.MethodPut, path, body, result)
+}
+
+// Patch performs a PATCH request with an optional JSON body.
+// Fiken uses PATCH both for partial updates and for actions such as
+// deleting sales and purchases, which take their input as query parameters
+// in path and have no body (pass nil).
+func (c *Client) Patch(path string, body interface{}, result interface{}) error {
+	return c.PatchContext(c.context(), path, body, result)
+}
+
+// PatchContext is like Patch but uses ctx for the request.
+func (c *Client) PatchContext(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.sendJSON(ctx, http.MethodPatch, path, body, result)
+}
+
+// Delete performs a DELETE request to the given path. Fiken answers with
+// 204 No Content.
+func (c *Client) Delete(path string) error {
+	return c.DeleteContext(c.context(), path)
+}
+
+// DeleteContext is like Delete but uses ctx for the request.
+func (c *Client) DeleteContext(ctx context.Context, path string) error {
+	return c.sendJSON(ctx, http.MethodDelete, path, nil, nil)
+}
+
+// request sends a request with an optional JSON body (nil for none) to
+// path and returns the response, whose body the caller must close.
+func (c *Client) request(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding request: %w", err)
+		}
+		reqBody = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	return c.doRequest(req)
+}
+
+// sendJSON sends a request like request and decodes the JSON response
+// into result, unless result is nil.
+func (c *Client) sendJSON(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	resp, err := c.request(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
 	defer resp.Body.Close()
 
 	if result != nil {
@@ -162,34 +515,45 @@ func (c *Client) Post(path string, body interface{}, result interface{}) error {
 			return fmt.Errorf("decoding response: %w", err)
 		}
 	}
-
 	return nil
 }
 
-// GetAllPages fetches all pages for a paginated endpoint.
-// The fetchPage function should perform the actual request and return results + whether there are more pages.
-func (c *Client) GetAllPages(path string, pageSize int, fetchPage func(page int) (int, error)) error {
-	if pageSize <= 0 {
-		pageSize = DefaultPageSize
+// Download fetches a file from an absolute URL, such as the downloadUrl of
+// an attachment, and writes it to w. The token is only sent if the URL is
+// on the API's host.
+func (c *Client) Download(rawURL string, w io.Writer) error {
+	return c.DownloadContext(c.context(), rawURL, w)
+}
+
+// DownloadContext is like Download but uses ctx for the request.
+func (c *Client) DownloadContext(ctx context.Context, rawURL string, w io.Writer) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
 	}
-	if pageSize > MaxPageSize {
-		pageSize = MaxPageSize
+	req.Header.Set("Accept", "*/*")
+
+	resp, err := c.doRequest(req)
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	page := 0
-	for {
-		pageCount, err := fetchPage(page)
-		if err != nil {
-			return err
-		}
-		page++
-		if page >= pageCount {
-			break
-		}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("reading response: %w", err)
 	}
 	return nil
 }
 
+// isAPIURL reports whether u has the scheme and host of the API.
+func (c *Client) isAPIURL(u *url.URL) bool {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == base.Scheme && u.Host == base.Host
+}
+
 func parsePagination(resp *http.Response) *PaginationInfo {
 	info := &PaginationInfo{}
 	if v := resp.Header.Get(HeaderPage); v != "" {
@@ -207,29 +571,20 @@ func parsePagination(resp *http.Response) *PaginationInfo {
 	return info
 }
 
+// idFromPath returns the trailing numeric ID of a resource URL path, or 0
+// if the last segment is not a number.
+func idFromPath(p string) int64 {
+	p = strings.TrimRight(p, "/")
+	id, err := strconv.ParseInt(p[strings.LastIndex(p, "/")+1:], 10, 64)
+	if err != nil || id < 0 {
+		return 0
+	}
+	return id
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) <= maxLen {
 		return s
 	}
 	return s[:maxLen] + "..."
 }
-
-// bytesReaderAt adapts a byte slice to io.ReaderAt.
-type bytesReaderAt struct {
-	data []byte
-}
-
-func newBytesReaderAt(data []byte) *bytesReaderAt {
-	return &bytesReaderAt{data: data}
-}
-
-func (b *bytesReaderAt) ReadAt(p []byte, off int64) (n int, err error) {
-	if off >= int64(len(b.data)) {
-		return 0, io.EOF
-	}
-	n = copy(p, b.data[off:])
-	if n < len(p) {
-		err = io.EOF
-	}
-	return
-}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestClient returns a client that sends its requests to handler,
+// without the rate limiter's delay between requests.
+func newTestClient(t *testing.T, handler http.Handler) (*Client, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	c := NewClient("test-token").WithBaseURL(srv.URL)
+	c.limiter.minDelay = 0
+	return c, srv
+}
+
+func TestIdFromPath(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"/api/v2/companies/acme/contacts/123", 123},
+		{"/api/v2/companies/acme/contacts/123/", 123},
+		{"/api/v2/companies/acme/invoices/drafts/9007199254740993", 9007199254740993},
+		{"123", 123},
+		{"/api/v2/companies/acme", 0},
+		{"/api/v2/companies/acme/contacts/-1", 0},
+		{"/api/v2/companies/acme/contacts/12a", 0},
+		{"/api/v2/companies/acme/contacts/99999999999999999999", 0},
+		{"/", 0},
+		{"", 0},
+	}
+	for _, tt := range tests {
+		if got := idFromPath(tt.in); got != tt.want {
+			t.Errorf("idFromPath(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPost(t *testing.T) {
+	type contact struct {
+		ContactId int64  `json:"contactId"`
+		Name      string `json:"name"`
+	}
+	var gets int
+	c, srv := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/contacts":
+			w.Header().Set("Location", "/contacts/42")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost && r.URL.Path == "/with-body":
+			w.Header().Set("Location", "/contacts/43")
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"contactId":43,"name":"From body"}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/elsewhere":
+			w.Header().Set("Location", "https://example.com/contacts/44")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost && r.URL.Path == "/action":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == "/no-id":
+			w.Header().Set("Location", "/contacts/new")
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == http.MethodPost && r.URL.Path == "/bad":
+			http.Error(w, "invalid contact", http.StatusBadRequest)
+		case r.Method == http.MethodGet && r.URL.Path == "/contacts/42":
+			gets++
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				t.Errorf("follow sent Authorization %q", r.Header.Get("Authorization"))
+			}
+			io.WriteString(w, `{"contactId":42,"name":"Acme AS"}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+			http.NotFound(w, r)
+		}
+	}))
+
+	created, err := c.Post("/contacts", map[string]string{"name": "Acme AS"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID != 42 || created.Location.String() != srv.URL+"/contacts/42" {
+		t.Errorf("Post = %d %v, want 42 %s/contacts/42", created.ID, created.Location, srv.URL)
+	}
+	if gets != 0 {
+		t.Errorf("Post with a nil result followed Location")
+	}
+
+	var got contact
+	if _, err := c.Post("/contacts", map[string]string{"name": "Acme AS"}, &got); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 1 || got != (contact{42, "Acme AS"}) {
+		t.Errorf("Post followed %d times and decoded %+v", gets, got)
+	}
+
+	got = contact{}
+	if _, err := c.Post("/with-body", nil, &got); err != nil {
+		t.Fatal(err)
+	}
+	if gets != 1 || got != (contact{43, "From body"}) {
+		t.Errorf("Post with a response body followed %d times and decoded %+v", gets, got)
+	}
+
+	if _, err := c.Post("/elsewhere", nil, &got); err == nil || !strings.Contains(err.Error(), "outside the API") {
+		t.Errorf("Post followed a Location on another host: %v", err)
+	}
+
+	created, err = c.Post("/action", nil, nil)
+	if err != nil || created.Location != nil || created.ID != 0 {
+		t.Errorf("Post without Location = %+v, %v", created, err)
+	}
+	if _, err := c.Post("/action", nil, &got); err == nil {
+		t.Error("Post with neither a body nor a Location succeeded")
+	}
+
+	var apiErr *APIError
+	if _, err := c.Post("/bad", nil, nil); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("Post error = %v, want an *APIError with status 400", err)
+	}
+
+	tests := []struct {
+		path    string
+		want    int64
+		wantErr string
+	}{
+		{"/contacts", 42, ""},
+		{"/action", 0, "no Location header"},
+		{"/no-id", 0, "no resource ID"},
+		{"/bad", 0, "400"},
+	}
+	for _, tt := range tests {
+		id, err := c.PostForID(tt.path, nil)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("PostForID(%s) error = %v, want %q", tt.path, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || id != tt.want {
+			t.Errorf("PostForID(%s) = %d, %v; want %d", tt.path, id, err, tt.want)
+		}
+	}
+}
+
+func TestDownloadToken(t *testing.T) {
+	var auth []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		io.WriteString(w, "%PDF")
+	})
+	c, srv := newTestClient(t, handler)
+	other := httptest.NewServer(handler)
+	t.Cleanup(other.Close)
+
+	for _, u := range []string{srv.URL + "/files/1", other.URL + "/files/1"} {
+		var buf strings.Builder
+		if err := c.Download(u, &buf); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != "%PDF" {
+			t.Errorf("Download(%s) = %q", u, buf.String())
+		}
+	}
+	if len(auth) != 2 || auth[0] != "Bearer test-token" || auth[1] != "" {
+		t.Errorf("Authorization headers = %q, want the token only for the API host", auth)
+	}
+}
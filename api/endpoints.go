@@ -7,16 +7,71 @@ const (
 	EndpointCompanies = "/companies"
 
 	// Endpoints under /companies/{slug}
-	EndpointAccounts        = "/companies/%s/accounts"
-	EndpointAccountBalances = "/companies/%s/accountBalances"
-	EndpointBankAccounts    = "/companies/%s/bankAccounts"
-	EndpointInbox           = "/companies/%s/inbox"
-	EndpointPurchases       = "/companies/%s/purchases"
-	EndpointSales           = "/companies/%s/sales"
-	EndpointInvoices        = "/companies/%s/invoices"
-	EndpointJournalEntries  = "/companies/%s/journalEntries"
-	EndpointTransactions    = "/companies/%s/transactions"
-	EndpointContacts        = "/companies/%s/contacts"
+	EndpointAccounts              = "/companies/%s/accounts"
+	EndpointAccountBalances       = "/companies/%s/accountBalances"
+	EndpointBankAccounts          = "/companies/%s/bankAccounts"
+	EndpointInbox                 = "/companies/%s/inbox"
+	EndpointPurchases             = "/companies/%s/purchases"
+	EndpointSales                 = "/companies/%s/sales"
+	EndpointInvoices              = "/companies/%s/invoices"
+	EndpointInvoicesSend          = "/companies/%s/invoices/send"
+	EndpointJournalEntries        = "/companies/%s/journalEntries"
+	EndpointGeneralJournalEntries = "/companies/%s/generalJournalEntries"
+	EndpointTransactions          = "/companies/%s/transactions"
+	EndpointContacts              = "/companies/%s/contacts"
+	EndpointProducts              = "/companies/%s/products"
+	EndpointProjects              = "/companies/%s/projects"
+
+	// Endpoints for a single resource under /companies/{slug}
+	EndpointAccountBalance          = "/companies/%s/accountBalances/%s"
+	EndpointBankAccount             = "/companies/%s/bankAccounts/%d"
+	EndpointContact                 = "/companies/%s/contacts/%d"
+	EndpointInboxDocument           = "/companies/%s/inbox/%d"
+	EndpointContactPersons          = "/companies/%s/contacts/%d/contactPerson"
+	EndpointContactPerson           = "/companies/%s/contacts/%d/contactPerson/%d"
+	EndpointProduct                 = "/companies/%s/products/%d"
+	EndpointProject                 = "/companies/%s/projects/%d"
+	EndpointInvoice                 = "/companies/%s/invoices/%d"
+	EndpointInvoiceAttachments      = "/companies/%s/invoices/%d/attachments"
+	EndpointSale                    = "/companies/%s/sales/%d"
+	EndpointSaleDelete              = "/companies/%s/sales/%d/delete"
+	EndpointSalePayments            = "/companies/%s/sales/%d/payments"
+	EndpointSaleAttachments         = "/companies/%s/sales/%d/attachments"
+	EndpointPurchase                = "/companies/%s/purchases/%d"
+	EndpointPurchaseDelete          = "/companies/%s/purchases/%d/delete"
+	EndpointPurchasePayments        = "/companies/%s/purchases/%d/payments"
+	EndpointPurchaseAttachments     = "/companies/%s/purchases/%d/attachments"
+	EndpointJournalEntry            = "/companies/%s/journalEntries/%d"
+	EndpointJournalEntryAttachments = "/companies/%s/journalEntries/%d/attachments"
+	EndpointTransaction             = "/companies/%s/transactions/%d"
+	EndpointTransactionDelete       = "/companies/%s/transactions/%d/delete"
+
+	// Invoice draft endpoints
+	EndpointInvoiceDrafts             = "/companies/%s/invoices/drafts"
+	EndpointInvoiceDraft              = "/companies/%s/invoices/drafts/%d"
+	EndpointInvoiceDraftCreateInvoice = "/companies/%s/invoices/drafts/%d/createInvoice"
+
+	// Offer endpoints
+	EndpointOffers                = "/companies/%s/offers"
+	EndpointOffer                 = "/companies/%s/offers/%d"
+	EndpointOfferDrafts           = "/companies/%s/offers/drafts"
+	EndpointOfferDraft            = "/companies/%s/offers/drafts/%d"
+	EndpointOfferDraftCreateOffer = "/companies/%s/offers/drafts/%d/createOffer"
+
+	// Order confirmation endpoints
+	EndpointOrderConfirmations                  = "/companies/%s/orderConfirmations"
+	EndpointOrderConfirmation                   = "/companies/%s/orderConfirmations/%d"
+	EndpointOrderConfirmationCreateInvoiceDraft = "/companies/%s/orderConfirmations/%d/createInvoiceDraft"
+	EndpointOrderConfirmationDrafts             = "/companies/%s/orderConfirmations/drafts"
+	EndpointOrderConfirmationDraft              = "/companies/%s/orderConfirmations/drafts/%d"
+	EndpointOrderConfirmationDraftCreate        = "/companies/%s/orderConfirmations/drafts/%d/createOrderConfirmation"
+
+	// Credit note endpoints
+	EndpointCreditNotes        = "/companies/%s/creditNotes"
+	EndpointCreditNote         = "/companies/%s/creditNotes/%d"
+	EndpointCreditNotesFull    = "/companies/%s/creditNotes/full"
+	EndpointCreditNotesPartial = "/companies/%s/creditNotes/partial"
+	EndpointCreditNotesSend    = "/companies/%s/creditNotes/send"
 )
 
 // Pagination defaults
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"iter"
+	"net/url"
+	"strconv"
+)
+
+// List iterates over a paginated list endpoint, fetching pages of
+// PageSize items as they are needed and stopping after the last page
+// (per the Fiken-Api-Page-Count header) or after Limit items:
+//
+//	purchases := api.NewList[api.Purchase](client, path, nil).Limit(50)
+//	for p, err := range purchases.All() {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Breaking out of the loop stops fetching. The requests use the client's
+// context, see Client.WithContext.
+type List[T any] struct {
+	client   *Client
+	path     string
+	params   url.Values
+	pageSize int
+	limit    int
+
+	resultCount int
+}
+
+// NewList returns a List of the items at path with the given query
+// parameters, which may be nil. params is copied, and its page and
+// pageSize are set by the List.
+func NewList[T any](client *Client, path string, params url.Values) *List[T] {
+	p := url.Values{}
+	for k, v := range params {
+		p[k] = append([]string(nil), v...)
+	}
+	return &List[T]{
+		client:   client,
+		path:     path,
+		params:   p,
+		pageSize: DefaultPageSize,
+	}
+}
+
+// PageSize sets the number of items fetched per request, between 1 and
+// MaxPageSize. Values <= 0 select DefaultPageSize.
+func (l *List[T]) PageSize(n int) *List[T] {
+	switch {
+	case n <= 0:
+		n = DefaultPageSize
+	case n > MaxPageSize:
+		n = MaxPageSize
+	}
+	l.pageSize = n
+	return l
+}
+
+// Limit sets the maximum number of items to return (<= 0 means no limit).
+func (l *List[T]) Limit(n int) *List[T] {
+	l.limit = n
+	return l
+}
+
+// ResultCount returns the total number of items on the server, as reported
+// by the Fiken-Api-Result-Count header of the last page fetched. It is 0
+// before the first page is fetched.
+func (l *List[T]) ResultCount() int {
+	return l.resultCount
+}
+
+// All returns an iterator over the items. An error ends the iteration and
+// is yielded with the zero T.
+func (l *List[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		params := url.Values{}
+		for k, v := range l.params {
+			params[k] = v
+		}
+		params.Set("pageSize", strconv.Itoa(l.pageSize))
+
+		n := 0
+		for page := 0; ; page++ {
+			params.Set("page", strconv.Itoa(page))
+			var items []T
+			pagination, err := l.client.GetWithParams(l.path, params, &items)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			l.resultCount = pagination.ResultCount
+
+			for _, item := range items {
+				if l.limit > 0 && n >= l.limit {
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+				n++
+			}
+
+			if (l.limit > 0 && n >= l.limit) || page+1 >= pagination.PageCount || len(items) == 0 {
+				return
+			}
+		}
+	}
+}
+
+// Collect fetches all items (up to Limit) into a slice.
+func (l *List[T]) Collect() ([]T, error) {
+	var items []T
+	for item, err := range l.All() {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
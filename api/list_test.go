@@ -0,0 +1,169 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// pagedServer serves the numbers 0..n-1 as a paginated list like Fiken
+// does, recording the query of each request.
+func pagedServer(t *testing.T, n int) (*Client, *[]url.Values) {
+	t.Helper()
+	var queries []url.Values
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q)
+		if q.Get("fail") != "" {
+			http.Error(w, "boom", http.StatusBadRequest)
+			return
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("pageSize"))
+		items := []int{}
+		for i := page * size; i < (page+1)*size && i < n; i++ {
+			items = append(items, i)
+		}
+		w.Header().Set(HeaderPage, strconv.Itoa(page))
+		w.Header().Set(HeaderPageSize, strconv.Itoa(size))
+		w.Header().Set(HeaderPageCount, strconv.Itoa((n+size-1)/size))
+		w.Header().Set(HeaderResultCount, strconv.Itoa(n))
+		json.NewEncoder(w).Encode(items)
+	}))
+	return c, &queries
+}
+
+func TestListCollect(t *testing.T) {
+	tests := []struct {
+		name      string
+		n         int
+		pageSize  int
+		limit     int
+		wantItems int
+		wantReqs  int
+		wantSize  string
+	}{
+		{"one page", 10, 25, 0, 10, 1, "25"},
+		{"exact pages", 50, 25, 0, 50, 2, "25"},
+		{"partial last page", 51, 25, 0, 51, 3, "25"},
+		{"empty", 0, 25, 0, 0, 1, "25"},
+		{"limit within first page", 100, 25, 10, 10, 1, "25"},
+		{"limit at page boundary", 100, 25, 50, 50, 2, "25"},
+		{"limit over several pages", 100, 25, 60, 60, 3, "25"},
+		{"limit above total", 30, 25, 100, 30, 2, "25"},
+		{"default page size", 30, 0, 0, 30, 2, "25"},
+		{"page size clamped", 250, 500, 0, 250, 3, "100"},
+	}
+	for _, tt := range tests {
+		c, queries := pagedServer(t, tt.n)
+		items, err := NewList[int](c, "/things", nil).PageSize(tt.pageSize).Limit(tt.limit).Collect()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(items) != tt.wantItems {
+			t.Errorf("%s: got %d items, want %d", tt.name, len(items), tt.wantItems)
+		}
+		for i, v := range items {
+			if v != i {
+				t.Errorf("%s: item %d = %d, pages out of order", tt.name, i, v)
+				break
+			}
+		}
+		if len(*queries) != tt.wantReqs {
+			t.Errorf("%s: made %d requests, want %d", tt.name, len(*queries), tt.wantReqs)
+		}
+		for i, q := range *queries {
+			if q.Get("page") != strconv.Itoa(i) || q.Get("pageSize") != tt.wantSize {
+				t.Errorf("%s: request %d asked for page %s of size %s", tt.name, i, q.Get("page"), q.Get("pageSize"))
+			}
+		}
+	}
+}
+
+func TestListBreak(t *testing.T) {
+	c, queries := pagedServer(t, 100)
+	list := NewList[int](c, "/things", nil).PageSize(10)
+	if list.ResultCount() != 0 {
+		t.Errorf("ResultCount before fetching = %d, want 0", list.ResultCount())
+	}
+	n := 0
+	for _, err := range list.All() {
+		if err != nil {
+			t.Fatal(err)
+		}
+		n++
+		if n == 15 {
+			break
+		}
+	}
+	if len(*queries) != 2 {
+		t.Errorf("breaking after 15 items made %d requests, want 2", len(*queries))
+	}
+	if list.ResultCount() != 100 {
+		t.Errorf("ResultCount = %d, want 100", list.ResultCount())
+	}
+}
+
+func TestListParams(t *testing.T) {
+	c, queries := pagedServer(t, 30)
+	params := url.Values{"settled": {"false"}, "page": {"7"}}
+	if _, err := NewList[int](c, "/things", params).Collect(); err != nil {
+		t.Fatal(err)
+	}
+	for i, q := range *queries {
+		if q.Get("settled") != "false" || q.Get("page") != strconv.Itoa(i) {
+			t.Errorf("request %d query = %v", i, q)
+		}
+	}
+	if len(params) != 2 || params.Get("page") != "7" || params.Get("pageSize") != "" {
+		t.Errorf("params were changed to %v", params)
+	}
+}
+
+func TestListError(t *testing.T) {
+	c, _ := pagedServer(t, 30)
+	items, err := NewList[int](c, "/things", url.Values{"fail": {"1"}}).Collect()
+	if err == nil || items != nil {
+		t.Fatalf("Collect = %v, %v; want the error", items, err)
+	}
+	if apiErr, ok := err.(*APIError); !ok || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("error = %v, want the *APIError", err)
+	}
+}
+
+func TestGetAllPages(t *testing.T) {
+	c, queries := pagedServer(t, 30)
+	var got []int
+	err := c.GetAllPages("/things", 8, func(page int) (int, error) {
+		params := url.Values{"page": {strconv.Itoa(page)}, "pageSize": {"8"}}
+		var items []int
+		pagination, err := c.GetWithParams("/things", params, &items)
+		if err != nil {
+			return 0, err
+		}
+		got = append(got, items...)
+		return pagination.PageCount, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 30 || got[0] != 0 || got[29] != 29 {
+		t.Errorf("got %v, want 0..29", got)
+	}
+	if len(*queries) != 4 {
+		t.Errorf("made %d requests, want 4", len(*queries))
+	}
+
+	wantErr := &APIError{StatusCode: http.StatusBadRequest}
+	calls := 0
+	err = c.GetAllPages("/things", 8, func(page int) (int, error) {
+		calls++
+		return 0, wantErr
+	})
+	if err != wantErr || calls != 1 {
+		t.Errorf("GetAllPages = %v after %d calls, want the first page's error", err, calls)
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries requests that fail with a
+// transient error: 429 Too Many Requests, a 5xx status or a network error.
+//
+// 429 responses are retried for every method, since Fiken rejects them
+// before doing anything. Other errors are only retried for idempotent
+// methods (GET, HEAD, PUT, DELETE), because a POST or PATCH may have taken
+// effect before the error, unless RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt.
+	// Zero disables retrying.
+	MaxRetries int
+
+	// BaseDelay is the backoff before the first retry. It doubles with
+	// each retry up to MaxDelay, and a random jitter of up to half the
+	// delay is subtracted. A MaxDelay of zero means no limit. A
+	// Retry-After header overrides the backoff; if it asks for a longer
+	// wait than MaxDelay, the request is not retried.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// RetryNonIdempotent also retries POST and PATCH requests on 5xx and
+	// network errors.
+	RetryNonIdempotent bool
+
+	// OnRetry, if set, is called before waiting to retry a request.
+	// attempt is the number of the retry, starting at 1.
+	OnRetry func(req *http.Request, err error, attempt int, wait time.Duration)
+}
+
+// DefaultRetryPolicy is the retry policy of clients made by NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  time.Second,
+	MaxDelay:   30 * time.Second,
+}
+
+// retryWait reports whether a request that failed with err on the given
+// attempt (0 for the first) should be retried, and how long to wait first.
+func (p RetryPolicy) retryWait(req *http.Request, err error, attempt int) (time.Duration, bool) {
+	if attempt >= p.MaxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+	// A body that cannot be rewound cannot be sent again.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+		case apiErr.StatusCode >= 500 && (p.RetryNonIdempotent || isIdempotent(req.Method)):
+		default:
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			// Retrying before the server allows it would only fail again.
+			if p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+				return 0, false
+			}
+			return apiErr.RetryAfter, true
+		}
+	} else if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return 0, false
+	}
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential backoff with jitter for a retry after
+// the given attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay
+	for i := 0; i < attempt && d < math.MaxInt64/2; i++ {
+		if p.MaxDelay > 0 && d >= p.MaxDelay {
+			break
+		}
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d - rand.N(d/2+1)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, given either as seconds or
+// as an HTTP date. It returns 0 if the header is missing or invalid.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,207 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 5 * time.Second},
+		{10, 5 * time.Second},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			d := p.backoff(tt.attempt)
+			if d < tt.max/2 || d > tt.max {
+				t.Fatalf("backoff(%d) = %v, want between %v and %v", tt.attempt, d, tt.max/2, tt.max)
+			}
+		}
+	}
+
+	if d := (RetryPolicy{}).backoff(3); d != 0 {
+		t.Errorf("backoff without a base delay = %v, want 0", d)
+	}
+	if d := (RetryPolicy{BaseDelay: time.Second}).backoff(4); d < 8*time.Second || d > 16*time.Second {
+		t.Errorf("backoff without a max delay = %v, want between 8s and 16s", d)
+	}
+	if d := (RetryPolicy{BaseDelay: time.Second}).backoff(100); d <= 0 {
+		t.Errorf("backoff(100) without a max delay = %v, want it not to overflow", d)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"", 0},
+		{"0", 0},
+		{"7", 7 * time.Second},
+		{"-3", 0},
+		{"soon", 0},
+		{"1.5", 0},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.in); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	date := time.Now().Add(90 * time.Second).UTC().Format(http.TimeFormat)
+	if got := parseRetryAfter(date); got < 80*time.Second || got > 90*time.Second {
+		t.Errorf("parseRetryAfter(%q) = %v, want about 90s", date, got)
+	}
+}
+
+func TestRetryWait(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	netErr := fmt.Errorf("request failed: %w", errors.New("connection reset"))
+	status := func(code int) error { return &APIError{StatusCode: code} }
+
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		method  string
+		err     error
+		attempt int
+		want    bool
+	}{
+		{"GET 500", p, http.MethodGet, status(500), 0, true},
+		{"GET 503 last retry", p, http.MethodGet, status(503), 1, true},
+		{"GET 503 retries used up", p, http.MethodGet, status(503), 2, false},
+		{"GET 404", p, http.MethodGet, status(404), 0, false},
+		{"GET network error", p, http.MethodGet, netErr, 0, true},
+		{"PUT 502", p, http.MethodPut, status(502), 0, true},
+		{"DELETE 500", p, http.MethodDelete, status(500), 0, true},
+		{"POST 429", p, http.MethodPost, status(429), 0, true},
+		{"PATCH 429", p, http.MethodPatch, status(429), 0, true},
+		{"POST 500", p, http.MethodPost, status(500), 0, false},
+		{"POST network error", p, http.MethodPost, netErr, 0, false},
+		{"POST 500 non-idempotent allowed", RetryPolicy{MaxRetries: 1, RetryNonIdempotent: true}, http.MethodPost, status(500), 0, true},
+		{"POST network error non-idempotent allowed", RetryPolicy{MaxRetries: 1, RetryNonIdempotent: true}, http.MethodPost, netErr, 0, true},
+		{"POST 400 non-idempotent allowed", RetryPolicy{MaxRetries: 1, RetryNonIdempotent: true}, http.MethodPost, status(400), 0, false},
+		{"retrying disabled", RetryPolicy{}, http.MethodGet, status(500), 0, false},
+	}
+	for _, tt := range tests {
+		req, _ := http.NewRequest(tt.method, "https://example.com/x", nil)
+		_, ok := tt.policy.retryWait(req, tt.err, tt.attempt)
+		if ok != tt.want {
+			t.Errorf("%s: retry = %v, want %v", tt.name, ok, tt.want)
+		}
+	}
+}
+
+func TestRetryWaitRetryAfter(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/x", nil)
+	tests := []struct {
+		name     string
+		maxDelay time.Duration
+		wantWait time.Duration
+		wantOK   bool
+	}{
+		{"within max delay", 10 * time.Second, 7 * time.Second, true},
+		{"equal to max delay", 7 * time.Second, 7 * time.Second, true},
+		{"longer than max delay", time.Second, 0, false},
+		{"no max delay", 0, 7 * time.Second, true},
+	}
+	for _, tt := range tests {
+		p := RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: tt.maxDelay}
+		wait, ok := p.retryWait(req, &APIError{StatusCode: 429, RetryAfter: 7 * time.Second}, 0)
+		if wait != tt.wantWait || ok != tt.wantOK {
+			t.Errorf("%s: retryWait = %v, %v; want %v, %v", tt.name, wait, ok, tt.wantWait, tt.wantOK)
+		}
+	}
+}
+
+func TestRetryWaitUnrewindableBody(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 1}
+	req, _ := http.NewRequest(http.MethodPut, "https://example.com/x", io.NopCloser(strings.NewReader("{}")))
+	if _, ok := p.retryWait(req, &APIError{StatusCode: 500}, 0); ok {
+		t.Error("retried a request whose body cannot be rewound")
+	}
+}
+
+func TestRetryWaitCanceled(t *testing.T) {
+	p := RetryPolicy{MaxRetries: 1}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/x", nil)
+	if _, ok := p.retryWait(req, &APIError{StatusCode: 500}, 0); ok {
+		t.Error("retried a canceled request")
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	var bodies []string
+	fail := 2
+	c, _ := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) <= fail {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	var retries []int
+	c = c.WithRetryPolicy(RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   time.Millisecond,
+		OnRetry: func(req *http.Request, err error, attempt int, wait time.Duration) {
+			retries = append(retries, attempt)
+		},
+	})
+
+	if err := c.Put("/x", map[string]int{"n": 1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("server got %d requests, want 3", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != `{"n":1}` {
+			t.Errorf("request %d body = %q, want it replayed", i+1, b)
+		}
+	}
+	if fmt.Sprint(retries) != "[1 2]" {
+		t.Errorf("OnRetry attempts = %v, want [1 2]", retries)
+	}
+
+	// A POST is not retried on a 5xx.
+	bodies, fail = nil, 1
+	_, err := c.Post("/x", map[string]int{"n": 1}, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("POST error = %v, want the 503", err)
+	}
+	if len(bodies) != 1 {
+		t.Errorf("POST was sent %d times, want 1", len(bodies))
+	}
+
+	// Retries stop after MaxRetries.
+	bodies, fail = nil, 10
+	if _, err := c.Get("/x", nil); err == nil {
+		t.Error("GET succeeded, want the 503")
+	}
+	if len(bodies) != 4 {
+		t.Errorf("GET was sent %d times, want 4", len(bodies))
+	}
+}
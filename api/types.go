@@ -1,6 +1,9 @@
 package api
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // PaginatedResponse wraps paginated list responses from Fiken.
 type PaginatedResponse struct {
@@ -12,25 +15,25 @@ type PaginatedResponse struct {
 
 // Company represents a Fiken company.
 type Company struct {
-	Name                 string `json:"name"`
-	Slug                 string `json:"slug"`
-	OrganizationNumber   string `json:"organizationNumber"`
-	VatType              string `json:"vatType"`
-	Address              Address `json:"address"`
-	PhoneNumber          string `json:"phoneNumber"`
-	Email                string `json:"email"`
-	CreationDate         string `json:"creationDate"`
-	HasApiAccess         bool   `json:"hasApiAccess"`
-	TestCompany          bool   `json:"testCompany"`
-	AccountingStartDate  string `json:"accountingStartDate"`
+	Name                string  `json:"name"`
+	Slug                string  `json:"slug"`
+	OrganizationNumber  string  `json:"organizationNumber"`
+	VatType             string  `json:"vatType"`
+	Address             Address `json:"address"`
+	PhoneNumber         string  `json:"phoneNumber"`
+	Email               string  `json:"email"`
+	CreationDate        string  `json:"creationDate"`
+	HasApiAccess        bool    `json:"hasApiAccess"`
+	TestCompany         bool    `json:"testCompany"`
+	AccountingStartDate string  `json:"accountingStartDate"`
 }
 
 type Address struct {
-	StreetAddress          string `json:"streetAddress"`
-	StreetAddressLine2     string `json:"streetAddressLine2,omitempty"`
-	City                   string `json:"city"`
-	PostCode               string `json:"postCode"`
-	Country                string `json:"country"`
+	StreetAddress      string `json:"streetAddress"`
+	StreetAddressLine2 string `json:"streetAddressLine2,omitempty"`
+	City               string `json:"city"`
+	PostCode           string `json:"postCode"`
+	Country            string `json:"country"`
 }
 
 type CompaniesResponse struct {
@@ -63,15 +66,25 @@ type AccountBalancesResponse struct {
 
 // BankAccount represents a bank account.
 type BankAccount struct {
-	BankAccountId    int64  `json:"bankAccountId"`
-	Name             string `json:"name"`
-	AccountCode      string `json:"accountCode"`
+	BankAccountId     int64  `json:"bankAccountId"`
+	Name              string `json:"name"`
+	AccountCode       string `json:"accountCode"`
 	BankAccountNumber string `json:"bankAccountNumber"`
-	Iban             string `json:"iban,omitempty"`
-	Bic              string `json:"bic,omitempty"`
-	ForeignService   string `json:"foreignService,omitempty"`
-	Type             string `json:"type"`
-	Inactive         bool   `json:"inactive"`
+	Iban              string `json:"iban,omitempty"`
+	Bic               string `json:"bic,omitempty"`
+	ForeignService    string `json:"foreignService,omitempty"`
+	Type              string `json:"type"`
+	Inactive          bool   `json:"inactive"`
+}
+
+// BankAccountRequest is the body for creating a bank account.
+type BankAccountRequest struct {
+	Name              string `json:"name"`
+	BankAccountNumber string `json:"bankAccountNumber"`
+	Iban              string `json:"iban,omitempty"`
+	Bic               string `json:"bic,omitempty"`
+	ForeignService    string `json:"foreignService,omitempty"`
+	Type              string `json:"type"`
 }
 
 type BankAccountsResponse struct {
@@ -81,12 +94,13 @@ type BankAccountsResponse struct {
 
 // InboxDocument represents an item in the EHF inbox.
 type InboxDocument struct {
-	DocumentId   int64     `json:"documentId"`
-	Name         string    `json:"name"`
-	Description  string    `json:"description,omitempty"`
-	Filename     string    `json:"filename"`
-	Status       string    `json:"status"`
-	CreatedDate  time.Time `json:"createdDate"`
+	DocumentId  int64     `json:"documentId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Filename    string    `json:"filename"`
+	Status      string    `json:"status"`
+	CreatedDate time.Time `json:"createdDate"`
+	DownloadUrl string    `json:"downloadUrl,omitempty"`
 }
 
 type InboxResponse struct {
@@ -96,30 +110,36 @@ type InboxResponse struct {
 
 // Purchase represents a purchase/expense.
 type Purchase struct {
-	PurchaseId     int64          `json:"purchaseId"`
-	TransactionId  int64          `json:"transactionId,omitempty"`
-	Identifier     string         `json:"identifier,omitempty"`
-	Date           string         `json:"date"`
-	DueDate        string         `json:"dueDate,omitempty"`
-	Kind           string         `json:"kind"`
-	Lines          []OrderLine    `json:"lines"`
-	Supplier       Contact        `json:"supplier,omitempty"`
-	Currency       string         `json:"currency"`
-	PaymentAccount string         `json:"paymentAccount,omitempty"`
-	Paid           bool           `json:"paid"`
-	TotalPaid      int64          `json:"totalPaid"`
-	TotalPaidInCurrency int64     `json:"totalPaidInCurrency"`
+	PurchaseId          int64        `json:"purchaseId"`
+	TransactionId       int64        `json:"transactionId,omitempty"`
+	Identifier          string       `json:"identifier,omitempty"`
+	Date                string       `json:"date"`
+	DueDate             string       `json:"dueDate,omitempty"`
+	Kind                string       `json:"kind"`
+	Lines               []OrderLine  `json:"lines"`
+	Supplier            Contact      `json:"supplier,omitempty"`
+	Currency            string       `json:"currency"`
+	PaymentAccount      string       `json:"paymentAccount,omitempty"`
+	PaymentDate         string       `json:"paymentDate,omitempty"`
+	Kid                 string       `json:"kid,omitempty"`
+	Paid                bool         `json:"paid"`
+	TotalPaid           int64        `json:"totalPaid"`
+	TotalPaidInCurrency int64        `json:"totalPaidInCurrency"`
+	Payments            []Payment    `json:"payments,omitempty"`
+	Attachments         []Attachment `json:"attachments,omitempty"`
+	Project             *Project     `json:"project,omitempty"`
+	Deleted             bool         `json:"deleted"`
 }
 
 type OrderLine struct {
-	Description    string `json:"description"`
-	Account        string `json:"account"`
-	NetAmount      int64  `json:"netAmount"`
-	VatAmount      int64  `json:"vatAmount"`
-	GrossAmount    int64  `json:"grossAmount,omitempty"`
-	NetAmountInCurrency int64 `json:"netAmountInCurrency,omitempty"`
-	VatAmountInCurrency int64 `json:"vatAmountInCurrency,omitempty"`
-	VatType        string `json:"vatType"`
+	Description         string `json:"description"`
+	Account             string `json:"account"`
+	NetAmount           int64  `json:"netAmount"`
+	VatAmount           int64  `json:"vatAmount"`
+	GrossAmount         int64  `json:"grossAmount,omitempty"`
+	NetAmountInCurrency int64  `json:"netAmountInCurrency,omitempty"`
+	VatAmountInCurrency int64  `json:"vatAmountInCurrency,omitempty"`
+	VatType             string `json:"vatType"`
 }
 
 type PurchasesResponse struct {
@@ -133,28 +153,79 @@ type PurchaseRequest struct {
 	DueDate        string      `json:"dueDate,omitempty"`
 	Kind           string      `json:"kind"`
 	Lines          []OrderLine `json:"lines"`
-	Supplier       *ContactRef `json:"supplier,omitempty"`
+	SupplierId     int64       `json:"supplierId,omitempty"`
 	Currency       string      `json:"currency"`
 	PaymentAccount string      `json:"paymentAccount,omitempty"`
+	PaymentDate    string      `json:"paymentDate,omitempty"`
 	Identifier     string      `json:"identifier,omitempty"`
+	Kid            string      `json:"kid,omitempty"`
+	ProjectId      int64       `json:"projectId,omitempty"`
+
+	// Deprecated: Fiken takes the supplier as SupplierId. Supplier is
+	// only kept for existing users of the package; its ContactId is sent
+	// as SupplierId when that is not set.
+	Supplier *ContactRef `json:"-"`
+}
+
+// MarshalJSON sends the deprecated Supplier as supplierId.
+func (r PurchaseRequest) MarshalJSON() ([]byte, error) {
+	type plain PurchaseRequest
+	if r.SupplierId == 0 && r.Supplier != nil {
+		r.SupplierId = r.Supplier.ContactId
+	}
+	return json.Marshal(plain(r))
 }
 
 type ContactRef struct {
-	ContactId int64 `json:"contactId,omitempty"`
+	ContactId       int64 `json:"contactId,omitempty"`
 	ContactPersonId int64 `json:"contactPersonId,omitempty"`
 }
 
+// Payment is a payment registered on a sale or purchase.
+type Payment struct {
+	PaymentId   int64  `json:"paymentId"`
+	Date        string `json:"date"`
+	Account     string `json:"account"`
+	Amount      int64  `json:"amount"`
+	AmountInNok int64  `json:"amountInNok,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	Fee         int64  `json:"fee,omitempty"`
+}
+
+// PaymentRequest is used to register a payment on a sale or purchase.
+type PaymentRequest struct {
+	Date        string `json:"date"`
+	Account     string `json:"account"`
+	Amount      int64  `json:"amount"`
+	AmountInNok int64  `json:"amountInNok,omitempty"`
+	Currency    string `json:"currency,omitempty"`
+	Fee         int64  `json:"fee,omitempty"`
+}
+
 // Sale represents a sale.
 type Sale struct {
-	SaleId     int64       `json:"saleId"`
-	Date       string      `json:"date"`
-	Kind       string      `json:"kind"`
-	Lines      []OrderLine `json:"lines"`
-	Customer   Contact     `json:"customer,omitempty"`
-	Currency   string      `json:"currency"`
-	DueDate    string      `json:"dueDate,omitempty"`
-	Paid       bool        `json:"paid"`
-	TotalPaid  int64       `json:"totalPaid"`
+	SaleId              int64        `json:"saleId"`
+	TransactionId       int64        `json:"transactionId,omitempty"`
+	SaleNumber          string       `json:"saleNumber,omitempty"`
+	Date                string       `json:"date"`
+	Kind                string       `json:"kind"`
+	Lines               []OrderLine  `json:"lines"`
+	Customer            Contact      `json:"customer,omitempty"`
+	Currency            string       `json:"currency"`
+	DueDate             string       `json:"dueDate,omitempty"`
+	Kid                 string       `json:"kid,omitempty"`
+	NetAmount           int64        `json:"netAmount"`
+	VatAmount           int64        `json:"vatAmount"`
+	Paid                bool         `json:"paid"`
+	Settled             bool         `json:"settled"`
+	SettledDate         string       `json:"settledDate,omitempty"`
+	TotalPaid           int64        `json:"totalPaid"`
+	TotalPaidInCurrency int64        `json:"totalPaidInCurrency,omitempty"`
+	OutstandingBalance  int64        `json:"outstandingBalance"`
+	PaymentAccount      string       `json:"paymentAccount,omitempty"`
+	Deleted             bool         `json:"deleted"`
+	Attachments         []Attachment `json:"attachments,omitempty"`
+	Project             *Project     `json:"project,omitempty"`
 }
 
 type SalesResponse struct {
@@ -162,20 +233,268 @@ type SalesResponse struct {
 	Sales []Sale `json:"sales"`
 }
 
-// Invoice represents an invoice.
-type Invoice struct {
-	InvoiceId      int64       `json:"invoiceId"`
-	InvoiceNumber  int64       `json:"invoiceNumber"`
-	IssueDate      string      `json:"issueDate"`
-	DueDate        string      `json:"dueDate"`
+// SaleRequest is used to create a new sale.
+type SaleRequest struct {
+	SaleNumber     string      `json:"saleNumber,omitempty"`
+	Date           string      `json:"date"`
+	Kind           string      `json:"kind"`
 	Lines          []OrderLine `json:"lines"`
-	Customer       Contact     `json:"customer,omitempty"`
-	Net            int64       `json:"net"`
-	Vat            int64       `json:"vat"`
-	Gross          int64       `json:"gross"`
+	CustomerId     int64       `json:"customerId,omitempty"`
 	Currency       string      `json:"currency"`
-	Paid           bool        `json:"paid"`
+	DueDate        string      `json:"dueDate,omitempty"`
 	Kid            string      `json:"kid,omitempty"`
+	PaymentAccount string      `json:"paymentAccount,omitempty"`
+	PaymentDate    string      `json:"paymentDate,omitempty"`
+	ProjectId      int64       `json:"projectId,omitempty"`
+}
+
+// Invoice represents an invoice.
+type Invoice struct {
+	InvoiceId     int64  `json:"invoiceId"`
+	InvoiceNumber int64  `json:"invoiceNumber"`
+	IssueDate     string `json:"issueDate"`
+	DueDate       string `json:"dueDate"`
+	// Lines was []OrderLine before; OrderLine has the fields of purchase
+	// lines, which Fiken does not return for invoices.
+	Lines             []InvoiceLine `json:"lines"`
+	Customer          Contact       `json:"customer,omitempty"`
+	Net               int64         `json:"net"`
+	Vat               int64         `json:"vat"`
+	Gross             int64         `json:"gross"`
+	Currency          string        `json:"currency"`
+	Paid              bool          `json:"paid"`
+	Settled           bool          `json:"settled"`
+	Kid               string        `json:"kid,omitempty"`
+	Cash              bool          `json:"cash"`
+	InvoiceText       string        `json:"invoiceText,omitempty"`
+	YourReference     string        `json:"yourReference,omitempty"`
+	OurReference      string        `json:"ourReference,omitempty"`
+	OrderReference    string        `json:"orderReference,omitempty"`
+	BankAccountNumber string        `json:"bankAccountNumber,omitempty"`
+	SentManually      bool          `json:"sentManually"`
+	InvoicePdf        *Attachment   `json:"invoicePdf,omitempty"`
+	Attachments       []Attachment  `json:"attachments,omitempty"`
+	Project           *Project      `json:"project,omitempty"`
+	CreatedDate       string        `json:"createdDate,omitempty"`
+}
+
+// InvoiceLine is a line on an invoice.
+type InvoiceLine struct {
+	Description   string  `json:"description,omitempty"`
+	Comment       string  `json:"comment,omitempty"`
+	ProductId     int64   `json:"productId,omitempty"`
+	ProductName   string  `json:"productName,omitempty"`
+	Quantity      float64 `json:"quantity"`
+	UnitPrice     int64   `json:"unitPrice"`
+	Discount      int64   `json:"discount,omitempty"`
+	Net           int64   `json:"net"`
+	Vat           int64   `json:"vat"`
+	Gross         int64   `json:"gross"`
+	VatType       string  `json:"vatType"`
+	VatInPercent  int64   `json:"vatInPercent,omitempty"`
+	IncomeAccount string  `json:"incomeAccount,omitempty"`
+}
+
+// InvoiceRequest is used to create a new invoice.
+type InvoiceRequest struct {
+	UUID            string               `json:"uuid,omitempty"`
+	IssueDate       string               `json:"issueDate"`
+	DueDate         string               `json:"dueDate"`
+	Lines           []InvoiceLineRequest `json:"lines"`
+	OurReference    string               `json:"ourReference,omitempty"`
+	YourReference   string               `json:"yourReference,omitempty"`
+	OrderReference  string               `json:"orderReference,omitempty"`
+	CustomerId      int64                `json:"customerId"`
+	ContactPersonId int64                `json:"contactPersonId,omitempty"`
+	Currency        string               `json:"currency,omitempty"`
+	InvoiceText     string               `json:"invoiceText,omitempty"`
+	BankAccountCode string               `json:"bankAccountCode"`
+	Cash            bool                 `json:"cash"`
+	PaymentAccount  string               `json:"paymentAccount,omitempty"`
+	ProjectId       int64                `json:"projectId,omitempty"`
+}
+
+// InvoiceLineRequest is a line on a new invoice.
+type InvoiceLineRequest struct {
+	Description   string  `json:"description,omitempty"`
+	Comment       string  `json:"comment,omitempty"`
+	ProductId     int64   `json:"productId,omitempty"`
+	Quantity      float64 `json:"quantity"`
+	UnitPrice     int64   `json:"unitPrice,omitempty"`
+	Discount      int64   `json:"discount,omitempty"`
+	VatType       string  `json:"vatType,omitempty"`
+	IncomeAccount string  `json:"incomeAccount,omitempty"`
+}
+
+// SendInvoiceRequest is used to send an invoice to the customer.
+type SendInvoiceRequest struct {
+	InvoiceId                  int64    `json:"invoiceId"`
+	Method                     []string `json:"method"`
+	IncludeDocumentAttachments bool     `json:"includeDocumentAttachments"`
+	RecipientName              string   `json:"recipientName,omitempty"`
+	RecipientEmail             string   `json:"recipientEmail,omitempty"`
+	Message                    string   `json:"message,omitempty"`
+}
+
+// CreditNote represents a credit note for an invoice.
+type CreditNote struct {
+	CreditNoteId        int64         `json:"creditNoteId"`
+	CreditNoteNumber    int64         `json:"creditNoteNumber"`
+	Kid                 string        `json:"kid,omitempty"`
+	IssueDate           string        `json:"issueDate"`
+	Net                 int64         `json:"net"`
+	Vat                 int64         `json:"vat"`
+	Gross               int64         `json:"gross"`
+	Currency            string        `json:"currency"`
+	CreditNoteText      string        `json:"creditNoteText,omitempty"`
+	YourReference       string        `json:"yourReference,omitempty"`
+	OurReference        string        `json:"ourReference,omitempty"`
+	Lines               []InvoiceLine `json:"lines"`
+	Customer            Contact       `json:"customer,omitempty"`
+	AssociatedInvoiceId int64         `json:"associatedInvoiceId,omitempty"`
+	Settled             bool          `json:"settled"`
+	CreditNotePdf       *Attachment   `json:"creditNotePdf,omitempty"`
+	Attachments         []Attachment  `json:"attachments,omitempty"`
+}
+
+// FullCreditNoteRequest is used to credit an entire invoice.
+type FullCreditNoteRequest struct {
+	IssueDate      string `json:"issueDate"`
+	InvoiceId      int64  `json:"invoiceId"`
+	CreditNoteText string `json:"creditNoteText,omitempty"`
+}
+
+// PartialCreditNoteRequest is used to credit part of an invoice.
+type PartialCreditNoteRequest struct {
+	IssueDate      string               `json:"issueDate"`
+	InvoiceId      int64                `json:"invoiceId"`
+	CreditNoteText string               `json:"creditNoteText,omitempty"`
+	OurReference   string               `json:"ourReference,omitempty"`
+	YourReference  string               `json:"yourReference,omitempty"`
+	Currency       string               `json:"currency,omitempty"`
+	Lines          []InvoiceLineRequest `json:"lines"`
+}
+
+// SendCreditNoteRequest is used to send a credit note to the customer.
+type SendCreditNoteRequest struct {
+	CreditNoteId               int64    `json:"creditNoteId"`
+	Method                     []string `json:"method"`
+	IncludeDocumentAttachments bool     `json:"includeDocumentAttachments"`
+	RecipientName              string   `json:"recipientName,omitempty"`
+	RecipientEmail             string   `json:"recipientEmail,omitempty"`
+	Message                    string   `json:"message,omitempty"`
+}
+
+// Draft is an invoice-like draft. Fiken uses the same draft format for
+// invoices, offers, order confirmations and credit notes; Type tells them apart.
+type Draft struct {
+	DraftId              int64        `json:"draftId"`
+	UUID                 string       `json:"uuid,omitempty"`
+	Type                 string       `json:"type"`
+	IssueDate            string       `json:"issueDate,omitempty"`
+	DaysUntilDueDate     int          `json:"daysUntilDueDate"`
+	InvoiceText          string       `json:"invoiceText,omitempty"`
+	Currency             string       `json:"currency,omitempty"`
+	YourReference        string       `json:"yourReference,omitempty"`
+	OurReference         string       `json:"ourReference,omitempty"`
+	OrderReference       string       `json:"orderReference,omitempty"`
+	Lines                []DraftLine  `json:"lines"`
+	Net                  int64        `json:"net"`
+	Gross                int64        `json:"gross"`
+	BankAccountNumber    string       `json:"bankAccountNumber,omitempty"`
+	PaymentAccount       string       `json:"paymentAccount,omitempty"`
+	Customers            []Contact    `json:"customers,omitempty"`
+	ContactPersonId      int64        `json:"contactPersonId,omitempty"`
+	Attachments          []Attachment `json:"attachments,omitempty"`
+	CreatedFromInvoiceId int64        `json:"createdFromInvoiceId,omitempty"`
+	ProjectId            int64        `json:"projectId,omitempty"`
+}
+
+// DraftLine is a line on a draft.
+type DraftLine struct {
+	Description   string  `json:"description,omitempty"`
+	Comment       string  `json:"comment,omitempty"`
+	ProductId     int64   `json:"productId,omitempty"`
+	Quantity      float64 `json:"quantity"`
+	UnitPrice     int64   `json:"unitPrice"`
+	Discount      int64   `json:"discount,omitempty"`
+	VatType       string  `json:"vatType,omitempty"`
+	IncomeAccount string  `json:"incomeAccount,omitempty"`
+	Net           int64   `json:"net,omitempty"`
+	Gross         int64   `json:"gross,omitempty"`
+}
+
+// DraftRequest is used to create or update a draft.
+type DraftRequest struct {
+	Type             string      `json:"type"`
+	IssueDate        string      `json:"issueDate,omitempty"`
+	DaysUntilDueDate int         `json:"daysUntilDueDate"`
+	InvoiceText      string      `json:"invoiceText,omitempty"`
+	Currency         string      `json:"currency,omitempty"`
+	YourReference    string      `json:"yourReference,omitempty"`
+	OurReference     string      `json:"ourReference,omitempty"`
+	OrderReference   string      `json:"orderReference,omitempty"`
+	Lines            []DraftLine `json:"lines"`
+	CustomerId       int64       `json:"customerId,omitempty"`
+	ContactPersonId  int64       `json:"contactPersonId,omitempty"`
+	BankAccountCode  string      `json:"bankAccountCode,omitempty"`
+	PaymentAccount   string      `json:"paymentAccount,omitempty"`
+	ProjectId        int64       `json:"projectId,omitempty"`
+}
+
+// Offer represents an offer (quote) sent to a customer.
+type Offer struct {
+	OfferId         int64         `json:"offerId"`
+	OfferDraftUuid  string        `json:"offerDraftUuid,omitempty"`
+	OfferNumber     int64         `json:"offerNumber"`
+	Date            string        `json:"date"`
+	Net             int64         `json:"net"`
+	Vat             int64         `json:"vat"`
+	Gross           int64         `json:"gross"`
+	Comment         string        `json:"comment,omitempty"`
+	YourReference   string        `json:"yourReference,omitempty"`
+	OurReference    string        `json:"ourReference,omitempty"`
+	OrderReference  string        `json:"orderReference,omitempty"`
+	Discount        int64         `json:"discount,omitempty"`
+	Address         *Address      `json:"address,omitempty"`
+	Lines           []InvoiceLine `json:"lines"`
+	Currency        string        `json:"currency"`
+	ContactId       int64         `json:"contactId,omitempty"`
+	ContactPersonId int64         `json:"contactPersonId,omitempty"`
+	ProjectId       int64         `json:"projectId,omitempty"`
+	Archived        bool          `json:"archived"`
+}
+
+// OrderConfirmation represents an order confirmation sent to a customer.
+type OrderConfirmation struct {
+	ConfirmationId        int64         `json:"confirmationId"`
+	ConfirmationDraftUuid string        `json:"confirmationDraftUuid,omitempty"`
+	ConfirmationNumber    int64         `json:"confirmationNumber"`
+	Date                  string        `json:"date"`
+	Net                   int64         `json:"net"`
+	Vat                   int64         `json:"vat"`
+	Gross                 int64         `json:"gross"`
+	Comment               string        `json:"comment,omitempty"`
+	YourReference         string        `json:"yourReference,omitempty"`
+	OurReference          string        `json:"ourReference,omitempty"`
+	OrderReference        string        `json:"orderReference,omitempty"`
+	Discount              int64         `json:"discount,omitempty"`
+	Address               *Address      `json:"address,omitempty"`
+	Lines                 []InvoiceLine `json:"lines"`
+	Currency              string        `json:"currency"`
+	ContactId             int64         `json:"contactId,omitempty"`
+	ContactPersonId       int64         `json:"contactPersonId,omitempty"`
+	ProjectId             int64         `json:"projectId,omitempty"`
+	Archived              bool          `json:"archived"`
+	CreatedInvoiceId      int64         `json:"createdInvoiceId,omitempty"`
+}
+
+// Attachment is a file attached to a document.
+type Attachment struct {
+	Identifier  string `json:"identifier,omitempty"`
+	DownloadUrl string `json:"downloadUrl,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+	Type        string `json:"type,omitempty"`
 }
 
 type InvoicesResponse struct {
@@ -183,17 +502,99 @@ type InvoicesResponse struct {
 	Invoices []Invoice `json:"invoices"`
 }
 
+// Project represents a project used to track income and costs.
+type Project struct {
+	ProjectId   int64    `json:"projectId"`
+	Number      string   `json:"number"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Contact     *Contact `json:"contact,omitempty"`
+	Completed   bool     `json:"completed"`
+}
+
+// ProjectRequest is used to create a project.
+type ProjectRequest struct {
+	Number      string `json:"number"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate,omitempty"`
+	ContactId   int64  `json:"contactId,omitempty"`
+	Completed   bool   `json:"completed"`
+}
+
+// ProjectUpdateRequest is used to update a project. Only the fields that
+// are not nil are changed; a pointer to "" clears a field.
+type ProjectUpdateRequest struct {
+	Number      *string `json:"number,omitempty"`
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	StartDate   *string `json:"startDate,omitempty"`
+	EndDate     *string `json:"endDate,omitempty"`
+	ContactId   *int64  `json:"contactId,omitempty"`
+	Completed   *bool   `json:"completed,omitempty"`
+}
+
+// Product represents a product in the product register.
+// UnitPrice is the net price in øre.
+type Product struct {
+	ProductId        int64   `json:"productId"`
+	Name             string  `json:"name"`
+	UnitPrice        int64   `json:"unitPrice"`
+	IncomeAccount    string  `json:"incomeAccount"`
+	VatType          string  `json:"vatType"`
+	Active           bool    `json:"active"`
+	ProductNumber    string  `json:"productNumber,omitempty"`
+	Stock            float64 `json:"stock,omitempty"`
+	Note             string  `json:"note,omitempty"`
+	CreatedDate      string  `json:"createdDate,omitempty"`
+	LastModifiedDate string  `json:"lastModifiedDate,omitempty"`
+}
+
+// ProductRequest is used to create or update a product.
+type ProductRequest struct {
+	Name          string  `json:"name"`
+	UnitPrice     int64   `json:"unitPrice"`
+	IncomeAccount string  `json:"incomeAccount"`
+	VatType       string  `json:"vatType"`
+	Active        bool    `json:"active"`
+	ProductNumber string  `json:"productNumber,omitempty"`
+	Stock         float64 `json:"stock,omitempty"`
+	Note          string  `json:"note,omitempty"`
+}
+
 // JournalEntry represents a journal entry.
 type JournalEntry struct {
-	JournalEntryId int64          `json:"journalEntryId"`
-	Date           string         `json:"date"`
-	Description    string         `json:"description"`
-	Lines          []JournalLine  `json:"lines"`
+	JournalEntryId      int64         `json:"journalEntryId"`
+	JournalEntryNumber  int64         `json:"journalEntryNumber,omitempty"`
+	TransactionId       int64         `json:"transactionId,omitempty"`
+	OffsetTransactionId int64         `json:"offsetTransactionId,omitempty"`
+	Date                string        `json:"date"`
+	Description         string        `json:"description"`
+	Lines               []JournalLine `json:"lines"`
+	Attachments         []Attachment  `json:"attachments,omitempty"`
 }
 
+// JournalLine is one line of a journal entry. Amount is always positive and
+// is debited to DebitAccount and/or credited to CreditAccount. Lines
+// returned by Fiken may instead use Account with a signed amount.
 type JournalLine struct {
-	Account     string `json:"account"`
-	DebitAmount int64  `json:"debitAmount,omitempty"`
+	Amount        int64  `json:"amount"`
+	Account       string `json:"account,omitempty"`
+	VatCode       string `json:"vatCode,omitempty"`
+	DebitAccount  string `json:"debitAccount,omitempty"`
+	DebitVatCode  string `json:"debitVatCode,omitempty"`
+	CreditAccount string `json:"creditAccount,omitempty"`
+	CreditVatCode string `json:"creditVatCode,omitempty"`
+	ProjectId     int64  `json:"projectId,omitempty"`
+
+	// Deprecated: Fiken's journal lines carry a single Amount with
+	// DebitAccount and/or CreditAccount; these are never set by the API
+	// and only kept for existing users of the package.
+	DebitAmount int64 `json:"debitAmount,omitempty"`
+	// Deprecated: See DebitAmount.
 	CreditAmount int64 `json:"creditAmount,omitempty"`
 }
 
@@ -202,12 +603,32 @@ type JournalEntriesResponse struct {
 	JournalEntries []JournalEntry `json:"journalEntries"`
 }
 
-// Transaction represents a financial transaction.
+// GeneralJournalRequest is used to create general journal entries.
+type GeneralJournalRequest struct {
+	Description    string                `json:"description,omitempty"`
+	Open           bool                  `json:"open"`
+	JournalEntries []JournalEntryRequest `json:"journalEntries"`
+}
+
+// JournalEntryRequest is a journal entry within a GeneralJournalRequest.
+type JournalEntryRequest struct {
+	Description string        `json:"description"`
+	Date        string        `json:"date"`
+	Lines       []JournalLine `json:"lines"`
+}
+
+// Transaction represents a financial transaction: the postings made by a
+// sale, purchase, payment or journal voucher. Entries hold the journal
+// entries with their lines and attached documents.
 type Transaction struct {
-	TransactionId int64  `json:"transactionId"`
-	Date          string `json:"date"`
-	Description   string `json:"description"`
-	Type          string `json:"type"`
+	TransactionId    int64          `json:"transactionId"`
+	Date             string         `json:"date,omitempty"`
+	Description      string         `json:"description"`
+	Type             string         `json:"type"`
+	CreatedDate      string         `json:"createdDate,omitempty"`
+	LastModifiedDate string         `json:"lastModifiedDate,omitempty"`
+	Entries          []JournalEntry `json:"entries,omitempty"`
+	Deleted          bool           `json:"deleted,omitempty"`
 }
 
 type TransactionsResponse struct {
@@ -217,20 +638,60 @@ type TransactionsResponse struct {
 
 // Contact represents a customer or supplier.
 type Contact struct {
-	ContactId          int64   `json:"contactId"`
-	Name               string  `json:"name"`
-	Email              string  `json:"email,omitempty"`
-	OrganizationNumber string  `json:"organizationNumber,omitempty"`
-	Customer           bool    `json:"customer"`
-	Supplier           bool    `json:"supplier"`
-	PhoneNumber        string  `json:"phoneNumber,omitempty"`
-	MemberNumber       int64   `json:"memberNumber,omitempty"`
-	Address            Address `json:"address,omitempty"`
-	Language           string  `json:"language,omitempty"`
-	Inactive           bool    `json:"inactive"`
+	ContactId                 int64           `json:"contactId"`
+	Name                      string          `json:"name"`
+	Email                     string          `json:"email,omitempty"`
+	OrganizationNumber        string          `json:"organizationNumber,omitempty"`
+	Customer                  bool            `json:"customer"`
+	Supplier                  bool            `json:"supplier"`
+	CustomerNumber            int64           `json:"customerNumber,omitempty"`
+	SupplierNumber            int64           `json:"supplierNumber,omitempty"`
+	PhoneNumber               string          `json:"phoneNumber,omitempty"`
+	MemberNumber              int64           `json:"memberNumber,omitempty"`
+	Address                   Address         `json:"address,omitempty"`
+	Language                  string          `json:"language,omitempty"`
+	Currency                  string          `json:"currency,omitempty"`
+	DaysUntilInvoicingDueDate int             `json:"daysUntilInvoicingDueDate,omitempty"`
+	Inactive                  bool            `json:"inactive"`
+	ContactPersons            []ContactPerson `json:"contactPerson,omitempty"`
+	CreatedDate               string          `json:"createdDate,omitempty"`
+	LastModifiedDate          string          `json:"lastModifiedDate,omitempty"`
+}
+
+// ContactRequest is used to create or update a contact.
+type ContactRequest struct {
+	Name                      string   `json:"name"`
+	Email                     string   `json:"email,omitempty"`
+	OrganizationNumber        string   `json:"organizationNumber,omitempty"`
+	PhoneNumber               string   `json:"phoneNumber,omitempty"`
+	MemberNumber              int64    `json:"memberNumber,omitempty"`
+	Customer                  bool     `json:"customer"`
+	Supplier                  bool     `json:"supplier"`
+	Address                   *Address `json:"address,omitempty"`
+	Language                  string   `json:"language,omitempty"`
+	Currency                  string   `json:"currency,omitempty"`
+	DaysUntilInvoicingDueDate int      `json:"daysUntilInvoicingDueDate,omitempty"`
+	Inactive                  bool     `json:"inactive"`
 }
 
 type ContactsResponse struct {
 	PaginatedResponse
 	Contacts []Contact `json:"contacts"`
 }
+
+// ContactPerson represents a person at a customer or supplier.
+type ContactPerson struct {
+	ContactPersonId int64    `json:"contactPersonId"`
+	Name            string   `json:"name"`
+	Email           string   `json:"email,omitempty"`
+	PhoneNumber     string   `json:"phoneNumber,omitempty"`
+	Address         *Address `json:"address,omitempty"`
+}
+
+// ContactPersonRequest is used to create or update a contact person.
+type ContactPersonRequest struct {
+	Name        string   `json:"name"`
+	Email       string   `json:"email"`
+	PhoneNumber string   `json:"phoneNumber,omitempty"`
+	Address     *Address `json:"address,omitempty"`
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPurchaseRequestSupplier(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PurchaseRequest
+		want string
+	}{
+		{"supplier ID", PurchaseRequest{SupplierId: 7}, `"supplierId":7`},
+		{"deprecated supplier", PurchaseRequest{Supplier: &ContactRef{ContactId: 8}}, `"supplierId":8`},
+		{"supplier ID wins", PurchaseRequest{SupplierId: 7, Supplier: &ContactRef{ContactId: 8}}, `"supplierId":7`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := fields["supplier"]; ok {
+			t.Errorf("%s: sent the deprecated supplier field: %s", tt.name, data)
+		}
+		if got := `"supplierId":` + string(fields["supplierId"]); got != tt.want {
+			t.Errorf("%s: %s, want %s", tt.name, data, tt.want)
+		}
+	}
+
+	// A pointer marshals the same way.
+	data, _ := json.Marshal(&PurchaseRequest{Supplier: &ContactRef{ContactId: 8}})
+	var fields map[string]json.RawMessage
+	json.Unmarshal(data, &fields)
+	if string(fields["supplierId"]) != "8" {
+		t.Errorf("marshalling a *PurchaseRequest gave %s", data)
+	}
+}
@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"slices"
+	"strings"
 
 	"github.com/jakoblind/fiken-cli/api"
 	"github.com/jakoblind/fiken-cli/output"
@@ -36,25 +38,11 @@ var accountsCmd = &cobra.Command{
 		if accountsToCode != "" {
 			params.Set("toAccount", accountsToCode)
 		}
-		params.Set("pageSize", "100")
 
 		endpoint := fmt.Sprintf(api.EndpointAccounts, slug)
-
-		var accounts []api.Account
-		page := 0
-		for {
-			params.Set("page", fmt.Sprintf("%d", page))
-			var pageAccounts []api.Account
-			pagination, err := client.GetWithParams(endpoint, params, &pageAccounts)
-			if err != nil {
-				return fmt.Errorf("fetching accounts: %w", err)
-			}
-			accounts = append(accounts, pageAccounts...)
-
-			if pagination == nil || page+1 >= pagination.PageCount {
-				break
-			}
-			page++
+		accounts, err := fetchPages[api.Account](client, endpoint, params, api.MaxPageSize, 0)
+		if err != nil {
+			return fmt.Errorf("fetching accounts: %w", err)
 		}
 
 		if jsonOutput {
@@ -77,6 +65,33 @@ var accountsCmd = &cobra.Command{
 	},
 }
 
+// validateAccounts checks that every account code exists in the chart of
+// accounts. Sub-ledger codes such as "2400:20001" are accepted when their
+// main account exists.
+func validateAccounts(client *api.Client, slug string, codes []string) error {
+	accounts, err := fetchPages[api.Account](client, fmt.Sprintf(api.EndpointAccounts, slug), nil, api.MaxPageSize, 0)
+	if err != nil {
+		return fmt.Errorf("fetching accounts: %w", err)
+	}
+
+	known := make(map[string]bool, len(accounts))
+	for _, a := range accounts {
+		known[a.Code] = true
+	}
+
+	var unknown []string
+	for _, code := range codes {
+		main, _, _ := strings.Cut(code, ":")
+		if !known[code] && !known[main] && !slices.Contains(unknown, code) {
+			unknown = append(unknown, code)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown account(s): %s. See 'fiken accounts' for valid codes", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
 func init() {
 	accountsCmd.Flags().StringVar(&accountsFromCode, "from", "", "Filter from account code")
 	accountsCmd.Flags().StringVar(&accountsToCode, "to", "", "Filter to account code")
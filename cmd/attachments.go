@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+// newAttachmentsCmd builds the attachments subcommand for a document type
+// such as "purchase" or "journal entry". command is the parent command
+// used in examples, and endpoint is the attachments endpoint format taking
+// the company slug and document ID.
+func newAttachmentsCmd(docName, command, endpoint string) *cobra.Command {
+	var input struct {
+		comment string
+		out     string
+	}
+
+	attachmentsCmd := &cobra.Command{
+		Use:   "attachments",
+		Short: fmt.Sprintf("Manage files attached to %s", withArticle(docName)),
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list <id>",
+		Short: fmt.Sprintf("List attachments on %s", withArticle(docName)),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docID, err := parseID(args[0], docName)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			attachments, err := fetchAttachments(client, fmt.Sprintf(endpoint, slug, docID))
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(attachments)
+			}
+
+			if len(attachments) == 0 {
+				output.PrintInfo("No attachments found.")
+				return nil
+			}
+
+			printAttachments(attachments)
+			return nil
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add <id> <file>...",
+		Short: fmt.Sprintf("Attach files to %s", withArticle(docName)),
+		Args:  cobra.MinimumNArgs(2),
+		Example: fmt.Sprintf(`  fiken %[1]s attachments add 123 receipt.pdf
+  fiken %[1]s attachments add 123 contract.pdf --comment "Signed contract"`, command),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docID, err := parseID(args[0], docName)
+			if err != nil {
+				return err
+			}
+			files := args[1:]
+			for _, path := range files {
+				if _, err := os.Stat(path); err != nil {
+					return err
+				}
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			var fields map[string]string
+			if input.comment != "" {
+				fields = map[string]string{"comment": input.comment}
+			}
+
+			for _, path := range files {
+				if err := uploadFile(client, fmt.Sprintf(endpoint, slug, docID), path, fields); err != nil {
+					return fmt.Errorf("attaching %s to %s %d: %w", path, docName, docID, err)
+				}
+				if !jsonOutput {
+					output.PrintSuccess(fmt.Sprintf("Attached %s to %s %d", filepath.Base(path), docName, docID))
+				}
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(map[string]int{"attached": len(files)})
+			}
+			return nil
+		},
+	}
+
+	downloadCmd := &cobra.Command{
+		Use:   "download <id> [identifier]",
+		Short: fmt.Sprintf("Download attachments of %s", withArticle(docName)),
+		Long: fmt.Sprintf(`Download the attachments of %s. Without an identifier all
+attachments are downloaded, and --output is a directory; with one,
+--output is the file to write (- for stdout).`, withArticle(docName)),
+		Args: cobra.RangeArgs(1, 2),
+		Example: fmt.Sprintf(`  fiken %[1]s attachments download 123 -o receipts/
+  fiken %[1]s attachments download 123 receipt.pdf -o -`, command),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docID, err := parseID(args[0], docName)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			attachments, err := fetchAttachments(client, fmt.Sprintf(endpoint, slug, docID))
+			if err != nil {
+				return err
+			}
+
+			if len(args) == 2 {
+				for i, a := range attachments {
+					if a.Identifier != args[1] {
+						continue
+					}
+					path := input.out
+					if path == "" {
+						path = attachmentFilename(a, docName, docID, i)
+					}
+					if err := downloadTo(client, a.DownloadUrl, path); err != nil {
+						return fmt.Errorf("downloading %s: %w", a.Identifier, err)
+					}
+					if path != "-" {
+						output.PrintSuccess(fmt.Sprintf("Saved %s to %s", a.Identifier, path))
+					}
+					return nil
+				}
+				return fmt.Errorf("%s %d has no attachment %q", docName, docID, args[1])
+			}
+
+			if len(attachments) == 0 {
+				output.PrintInfo("No attachments found.")
+				return nil
+			}
+			if input.out == "-" {
+				return fmt.Errorf("give an identifier to write a single attachment to stdout")
+			}
+
+			dir := input.out
+			if dir == "" {
+				dir = "."
+			}
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("creating directory: %w", err)
+			}
+			for i, a := range attachments {
+				path := filepath.Join(dir, attachmentFilename(a, docName, docID, i))
+				if err := downloadTo(client, a.DownloadUrl, path); err != nil {
+					return fmt.Errorf("downloading %s: %w", a.Identifier, err)
+				}
+				output.PrintSuccess(fmt.Sprintf("Saved %s", path))
+			}
+			return nil
+		},
+	}
+
+	addCmd.Flags().StringVar(&input.comment, "comment", "", "Comment on the attachment")
+	downloadCmd.Flags().StringVarP(&input.out, "output", "o", "", "Output file, or directory when downloading all (default current directory)")
+
+	attachmentsCmd.AddCommand(listCmd)
+	attachmentsCmd.AddCommand(addCmd)
+	attachmentsCmd.AddCommand(downloadCmd)
+	return attachmentsCmd
+}
+
+// fetchAttachments fetches the attachments at an attachments endpoint.
+func fetchAttachments(client *api.Client, endpoint string) ([]api.Attachment, error) {
+	attachments, err := fetchPages[api.Attachment](client, endpoint, nil, api.MaxPageSize, 0)
+	if err != nil {
+		return nil, fmt.Errorf("fetching attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// attachmentFilename returns a local file name for the i-th attachment of
+// a document: its identifier when that looks like a file name, or else
+// one made up from the document.
+func attachmentFilename(a api.Attachment, docName string, docID int64, i int) string {
+	name := filepath.Base(a.Identifier)
+	if filepath.Ext(name) != "" && name != "." {
+		return name
+	}
+	return fmt.Sprintf("%s-%d-%d", strings.ReplaceAll(docName, " ", "-"), docID, i+1)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+func TestUploadFile(t *testing.T) {
+	type upload struct {
+		fields   map[string]string
+		filename string
+		content  string
+	}
+	var got []upload
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/companies/acme/purchases/5/attachments" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		u := upload{fields: map[string]string{}}
+		for k, v := range r.MultipartForm.Value {
+			u.fields[k] = strings.Join(v, ",")
+		}
+		if files := r.MultipartForm.File["file"]; len(files) == 1 {
+			f, _ := files[0].Open()
+			data, _ := io.ReadAll(f)
+			f.Close()
+			u.filename, u.content = files[0].Filename, string(data)
+		}
+		got = append(got, u)
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "receipt.pdf")
+	if err := os.WriteFile(path, []byte("%PDF-1.4"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	endpoint := "/companies/acme/purchases/5/attachments"
+
+	tests := []struct {
+		name   string
+		fields map[string]string
+		want   upload
+	}{
+		{
+			name: "no fields",
+			want: upload{fields: map[string]string{"filename": "receipt.pdf"}, filename: "receipt.pdf", content: "%PDF-1.4"},
+		},
+		{
+			name:   "comment",
+			fields: map[string]string{"comment": "Signed contract"},
+			want:   upload{fields: map[string]string{"filename": "receipt.pdf", "comment": "Signed contract"}, filename: "receipt.pdf", content: "%PDF-1.4"},
+		},
+		{
+			name:   "fields override the filename",
+			fields: map[string]string{"filename": "kvittering.pdf", "attachToSale": "true"},
+			want:   upload{fields: map[string]string{"filename": "kvittering.pdf", "attachToSale": "true"}, filename: "receipt.pdf", content: "%PDF-1.4"},
+		},
+	}
+	for _, tt := range tests {
+		got = nil
+		if err := uploadFile(client, endpoint, path, tt.fields); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+			t.Errorf("%s: server got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if err := uploadFile(client, endpoint, filepath.Join(dir, "missing.pdf"), nil); err == nil || !strings.Contains(err.Error(), "opening file") {
+		t.Errorf("uploading a missing file: error = %v", err)
+	}
+	if err := uploadFile(client, "/companies/acme/sales/5/attachments", path, nil); err == nil {
+		t.Error("uploading to an unknown endpoint succeeded")
+	}
+}
+
+func TestAttachmentFilename(t *testing.T) {
+	tests := []struct {
+		identifier string
+		want       string
+	}{
+		{"receipt.pdf", "receipt.pdf"},
+		{"scans/2024/receipt.pdf", "receipt.pdf"},
+		{"../../etc/passwd.txt", "passwd.txt"},
+		{"12345", "journal-entry-7-2"},
+		{"", "journal-entry-7-2"},
+	}
+	for _, tt := range tests {
+		got := attachmentFilename(api.Attachment{Identifier: tt.identifier}, "journal entry", 7, 1)
+		if got != tt.want {
+			t.Errorf("attachmentFilename(%q) = %q, want %q", tt.identifier, got, tt.want)
+		}
+	}
+}
@@ -25,8 +25,7 @@ var balancesCmd = &cobra.Command{
 
 		endpoint := fmt.Sprintf(api.EndpointAccountBalances, slug)
 
-		var balances []api.AccountBalance
-		_, err = client.Get(endpoint, &balances)
+		balances, err := fetchPages[api.AccountBalance](client, endpoint, nil, api.MaxPageSize, 0)
 		if err != nil {
 			return fmt.Errorf("fetching balances: %w", err)
 		}
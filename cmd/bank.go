@@ -2,12 +2,35 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jakoblind/fiken-cli/api"
 	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	bankInactive     bool
+	bankBalancesDate string
+)
+
+// bankCreateInput holds the flags for bank create.
+var bankCreateInput struct {
+	name           string
+	number         string
+	accountType    string
+	iban           string
+	bic            string
+	foreignService string
+}
+
+// bankAccountTypes are the bank account types Fiken accepts.
+var bankAccountTypes = []string{"normal", "tax_deduction", "foreign", "credit_card"}
+
 var bankCmd = &cobra.Command{
 	Use:   "bank",
 	Short: "Manage bank accounts",
@@ -27,10 +50,14 @@ var bankListCmd = &cobra.Command{
 			return err
 		}
 
+		params := url.Values{}
+		if cmd.Flags().Changed("inactive") {
+			params.Set("inactive", strconv.FormatBool(bankInactive))
+		}
+
 		endpoint := fmt.Sprintf(api.EndpointBankAccounts, slug)
 
-		var bankAccounts []api.BankAccount
-		_, err = client.Get(endpoint, &bankAccounts)
+		bankAccounts, err := fetchPages[api.BankAccount](client, endpoint, params, api.MaxPageSize, 0)
 		if err != nil {
 			return fmt.Errorf("fetching bank accounts: %w", err)
 		}
@@ -65,7 +92,286 @@ var bankListCmd = &cobra.Command{
 	},
 }
 
+var bankGetCmd = &cobra.Command{
+	Use:   "get <bankAccountId>",
+	Short: "Show a bank account",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		bankAccountID, err := parseID(args[0], "bank account")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var ba api.BankAccount
+		_, err = client.Get(fmt.Sprintf(api.EndpointBankAccount, slug, bankAccountID), &ba)
+		if err != nil {
+			return fmt.Errorf("fetching bank account: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(ba)
+		}
+
+		today := time.Now().Format("2006-01-02")
+		balance, err := fetchAccountBalance(client, slug, ba.AccountCode, today)
+		if err != nil {
+			return err
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", ba.BankAccountId))
+		d.Add("Name", ba.Name)
+		d.Add("Account", ba.AccountCode)
+		d.Add("Bank account", ba.BankAccountNumber)
+		d.Add("IBAN", ba.Iban)
+		d.Add("BIC", ba.Bic)
+		d.Add("Foreign service", ba.ForeignService)
+		d.Add("Type", ba.Type)
+		d.Add("Active", yesNo(!ba.Inactive))
+		d.Add("Balance", fmt.Sprintf("%s (%s)", output.FormatAmount(balance), today))
+		d.Print()
+		return nil
+	},
+}
+
+var bankBalancesCmd = &cobra.Command{
+	Use:   "balances",
+	Short: "Show the ledger balance of each bank account",
+	Long: `Show the ledger balance of each active bank account on a date,
+with the total across all accounts. The date defaults to today.`,
+	Example: `  fiken bank balances
+  fiken bank balances --date 2024-12-31`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		date := bankBalancesDate
+		if date == "" {
+			date = time.Now().Format("2006-01-02")
+		}
+		if err := validateDate(date); err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		params.Set("inactive", "false")
+
+		bankAccounts, err := fetchPages[api.BankAccount](client, fmt.Sprintf(api.EndpointBankAccounts, slug), params, api.MaxPageSize, 0)
+		if err != nil {
+			return fmt.Errorf("fetching bank accounts: %w", err)
+		}
+
+		type bankBalance struct {
+			BankAccountId     int64  `json:"bankAccountId"`
+			Name              string `json:"name"`
+			AccountCode       string `json:"accountCode"`
+			BankAccountNumber string `json:"bankAccountNumber"`
+			Date              string `json:"date"`
+			Balance           int64  `json:"balance"`
+		}
+
+		var balances []bankBalance
+		var total int64
+		for _, ba := range bankAccounts {
+			if ba.Inactive {
+				continue
+			}
+			balance, err := fetchAccountBalance(client, slug, ba.AccountCode, date)
+			if err != nil {
+				return err
+			}
+			balances = append(balances, bankBalance{
+				BankAccountId:     ba.BankAccountId,
+				Name:              ba.Name,
+				AccountCode:       ba.AccountCode,
+				BankAccountNumber: ba.BankAccountNumber,
+				Date:              date,
+				Balance:           balance,
+			})
+			total += balance
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(balances)
+		}
+
+		if len(balances) == 0 {
+			output.PrintInfo("No active bank accounts found.")
+			return nil
+		}
+
+		table := output.NewTable("NAME", "ACCOUNT", "BANK ACCOUNT", "BALANCE")
+		for _, b := range balances {
+			table.AddRow(b.Name, b.AccountCode, b.BankAccountNumber, output.FormatAmount(b.Balance))
+		}
+		table.Print()
+
+		fmt.Printf("\nTotal %s on %s\n", output.FormatAmount(total), date)
+		return nil
+	},
+}
+
+var bankCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a bank account",
+	Long: `Create a bank account. Fiken sets up the ledger account (1920:xxxxx)
+for it. The type is one of normal, tax_deduction, foreign or credit_card.`,
+	Example: `  fiken bank create --name "Driftskonto" --number 12345678903
+  fiken bank create --name "EUR account" --number 12345678903 --type foreign \
+    --iban NO9386011117947 --bic DNBANOKK`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if bankCreateInput.name == "" || bankCreateInput.number == "" {
+			return fmt.Errorf("--name and --number are required")
+		}
+
+		req := api.BankAccountRequest{
+			Name:              bankCreateInput.name,
+			BankAccountNumber: strings.ReplaceAll(bankCreateInput.number, ".", ""),
+			Iban:              strings.ReplaceAll(bankCreateInput.iban, " ", ""),
+			Bic:               bankCreateInput.bic,
+			ForeignService:    bankCreateInput.foreignService,
+			Type:              strings.ToLower(bankCreateInput.accountType),
+		}
+		if !slices.Contains(bankAccountTypes, req.Type) {
+			return fmt.Errorf("invalid type %q (valid: %s)", bankCreateInput.accountType, strings.Join(bankAccountTypes, ", "))
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		bankAccountID, err := client.PostForID(fmt.Sprintf(api.EndpointBankAccounts, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating bank account: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"bankAccountId": bankAccountID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Bank account '%s' created (ID %d)", req.Name, bankAccountID))
+		return nil
+	},
+}
+
+// fetchAccountBalance returns the balance of a ledger account on date.
+func fetchAccountBalance(client *api.Client, slug, accountCode, date string) (int64, error) {
+	params := url.Values{}
+	params.Set("date", date)
+
+	var balance api.AccountBalance
+	_, err := client.GetWithParams(fmt.Sprintf(api.EndpointAccountBalance, slug, accountCode), params, &balance)
+	if err != nil {
+		return 0, fmt.Errorf("fetching balance for %s: %w", accountCode, err)
+	}
+	return balance.Balance, nil
+}
+
+// resolveBankAccountCode returns the ledger account code (e.g. "1920:10001")
+// of a bank account given by account code, ID, bank account number or name.
+// If ref is empty, the company's only active bank account is used.
+func resolveBankAccountCode(client *api.Client, slug, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if strings.Contains(ref, ":") {
+		return ref, nil
+	}
+	ba, err := resolveBankAccount(client, slug, ref)
+	if err != nil {
+		return "", err
+	}
+	return ba.AccountCode, nil
+}
+
+// resolveBankAccount finds a bank account by account code, ID, bank
+// account number or name. If ref is empty, the company's only active bank
+// account is used.
+func resolveBankAccount(client *api.Client, slug, ref string) (api.BankAccount, error) {
+	ref = strings.TrimSpace(ref)
+
+	bankAccounts, err := fetchPages[api.BankAccount](client, fmt.Sprintf(api.EndpointBankAccounts, slug), nil, api.MaxPageSize, 0)
+	if err != nil {
+		return api.BankAccount{}, fmt.Errorf("fetching bank accounts: %w", err)
+	}
+
+	var candidates []api.BankAccount
+	for _, ba := range bankAccounts {
+		if ref == "" {
+			if !ba.Inactive {
+				candidates = append(candidates, ba)
+			}
+			continue
+		}
+		if fmt.Sprintf("%d", ba.BankAccountId) == ref || ba.BankAccountNumber == ref ||
+			ba.AccountCode == ref || strings.EqualFold(ba.Name, ref) {
+			candidates = append(candidates, ba)
+		}
+	}
+
+	switch len(candidates) {
+	case 1:
+		return candidates[0], nil
+	case 0:
+		if ref == "" {
+			return api.BankAccount{}, fmt.Errorf("no active bank accounts found")
+		}
+		return api.BankAccount{}, fmt.Errorf("no bank account found matching %q", ref)
+	default:
+		names := make([]string, len(candidates))
+		for i, ba := range candidates {
+			names[i] = fmt.Sprintf("  %s (%s)", ba.Name, ba.AccountCode)
+		}
+		return api.BankAccount{}, fmt.Errorf("multiple bank accounts found. Use --bank-account to select one:\n%s", strings.Join(names, "\n"))
+	}
+}
+
+// resolvePaymentAccount returns the ledger account to register a payment
+// on. Plain four-digit account codes (such as 1900 for cash) are used as-is;
+// anything else is resolved as a bank account.
+func resolvePaymentAccount(client *api.Client, slug, ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if len(ref) == 4 && strings.Trim(ref, "0123456789") == "" {
+		return ref, nil
+	}
+	return resolveBankAccountCode(client, slug, ref)
+}
+
 func init() {
+	bankListCmd.Flags().BoolVar(&bankInactive, "inactive", false, "Only inactive bank accounts (--inactive=false for active)")
+
+	bankBalancesCmd.Flags().StringVar(&bankBalancesDate, "date", "", "Balance date (YYYY-MM-DD, default today)")
+
+	bankCreateCmd.Flags().StringVar(&bankCreateInput.name, "name", "", "Account name")
+	bankCreateCmd.Flags().StringVar(&bankCreateInput.number, "number", "", "Bank account number")
+	bankCreateCmd.Flags().StringVar(&bankCreateInput.accountType, "type", "normal", "Account type (normal, tax_deduction, foreign, credit_card)")
+	bankCreateCmd.Flags().StringVar(&bankCreateInput.iban, "iban", "", "IBAN")
+	bankCreateCmd.Flags().StringVar(&bankCreateInput.bic, "bic", "", "BIC (SWIFT)")
+	bankCreateCmd.Flags().StringVar(&bankCreateInput.foreignService, "foreign-service", "", "Foreign payment service")
+
 	bankCmd.AddCommand(bankListCmd)
+	bankCmd.AddCommand(bankGetCmd)
+	bankCmd.AddCommand(bankBalancesCmd)
+	bankCmd.AddCommand(bankCreateCmd)
 	rootCmd.AddCommand(bankCmd)
 }
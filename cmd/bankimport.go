@@ -0,0 +1,483 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+// bankImportInput holds the flags for bank import.
+var bankImportInput struct {
+	account   string
+	currency  string
+	format    string
+	apply     bool
+	csvConfig string
+	csv       csvFormat
+}
+
+// reconcileLookbackDays is how long before the first statement line a
+// purchase may be dated and still be matched.
+const reconcileLookbackDays = 90
+
+// reconcileDoc is an unpaid sale or purchase that statement lines are
+// matched against.
+type reconcileDoc struct {
+	kind        string // "sale" or "purchase"
+	id          int64
+	number      string
+	date        string
+	dueDate     string
+	kid         string
+	contact     string
+	outstanding int64
+	currency    string
+	matched     bool
+}
+
+// reconcileMatch is a statement line with its proposed match, if any.
+type reconcileMatch struct {
+	Line      statementLine `json:"line"`
+	Kind      string        `json:"kind,omitempty"`
+	ID        int64         `json:"id,omitempty"`
+	Number    string        `json:"number,omitempty"`
+	Amount    int64         `json:"amount,omitempty"`
+	Basis     string        `json:"basis,omitempty"`
+	Note      string        `json:"note,omitempty"`
+	PaymentId int64         `json:"paymentId,omitempty"`
+}
+
+var bankImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Match a bank statement against unpaid sales and purchases",
+	Long: `Read a bank statement and match each line against unpaid sales
+(money in) and purchases (money out).
+
+The statement is an ISO 20022 CAMT.053 XML file or a CSV export. CSV
+columns are found from the header row using the names Norwegian banks
+use (Dato, Beløp, Inn på konto, Ut fra konto, Forklaring, KID, ...);
+other layouts are described with the --csv-* flags or a --csv-config
+JSON/YAML file with the same keys (delimiter, dateFormat, date, amount,
+in, out, text, reference, counterparty). Columns are header names or
+1-based column numbers, and dateFormat is a Go time layout.
+
+A line matches a document when its KID is the document's KID, or when its
+amount equals the outstanding amount and the counterparty name or the
+date (on or after the document date, within 30 days of the due date)
+agrees. Lines that match more than one document are left for manual
+handling. Only purchases dated up to 90 days before the first line are
+considered.
+
+A line is only matched against documents in its own currency. Lines
+without a currency are in the bank account's currency, which is NOK
+unless --currency is given; for foreign accounts --currency is required
+when the statement does not give the currency.
+
+Without --apply only a preview is shown. With --apply the matched
+payments are registered on the bank account.`,
+	Example: `  fiken bank import statement.xml --account 1920:10001
+  fiken bank import statement.xml --account 1920:10001 --apply
+  fiken bank import export.csv --csv-date "Bokført" --csv-amount 4 --csv-date-format 2006-01-02`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		csvFmt := csvFormat{}
+		if bankImportInput.csvConfig != "" {
+			if err := readInputFile(bankImportInput.csvConfig, &csvFmt); err != nil {
+				return err
+			}
+		}
+		mergeCSVFormat(&csvFmt, bankImportInput.csv)
+
+		lines, err := readStatement(args[0], bankImportInput.format, csvFmt)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			output.PrintInfo("No booked transactions found in the statement.")
+			return nil
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		account, err := resolveBankAccount(client, slug, bankImportInput.account)
+		if err != nil {
+			return err
+		}
+		accountCode := account.AccountCode
+		currency := strings.ToUpper(bankImportInput.currency)
+		if currency == "" && account.Type != "foreign" {
+			currency = "NOK"
+		}
+
+		docs, err := fetchReconcileDocs(client, slug, lines)
+		if err != nil {
+			return err
+		}
+
+		matches := make([]reconcileMatch, len(lines))
+		matched := 0
+		for i, line := range lines {
+			matches[i] = matchStatementLine(line, currency, docs)
+			if matches[i].ID != 0 {
+				matched++
+			}
+		}
+
+		if bankImportInput.apply && matched > 0 {
+			if !confirm(fmt.Sprintf("Register %d payments on %s?", matched, accountCode)) {
+				output.PrintInfo("Aborted.")
+				return nil
+			}
+			registered := 0
+			for i := range matches {
+				m := &matches[i]
+				if m.ID == 0 {
+					continue
+				}
+				endpoint := api.EndpointSalePayments
+				if m.Kind == "purchase" {
+					endpoint = api.EndpointPurchasePayments
+				}
+				req := api.PaymentRequest{
+					Date:     m.Line.Date,
+					Account:  accountCode,
+					Amount:   m.Amount,
+					Currency: m.Line.Currency,
+				}
+				m.PaymentId, err = client.PostForID(fmt.Sprintf(endpoint, slug, m.ID), req)
+				if err != nil {
+					return fmt.Errorf("registering payment on %s %d (%d payments registered before this): %w", m.Kind, m.ID, registered, err)
+				}
+				registered++
+			}
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(matches)
+		}
+
+		table := output.NewTable("DATE", "AMOUNT", "COUNTERPARTY", "TEXT", "MATCH", "BASIS")
+		for _, m := range matches {
+			match, basis := "", m.Basis
+			if m.ID != 0 {
+				match = fmt.Sprintf("%s %s (ID %d)", m.Kind, m.Number, m.ID)
+			} else {
+				basis = m.Note
+			}
+			text := m.Line.Text
+			if m.Line.Reference != "" {
+				text = "KID " + m.Line.Reference
+			}
+			table.AddRow(
+				m.Line.Date,
+				output.FormatAmount(m.Line.Amount),
+				shorten(m.Line.Counterparty, 30),
+				shorten(text, 40),
+				match,
+				basis,
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d lines, %d matched, %d unmatched\n", len(lines), matched, len(lines)-matched)
+		switch {
+		case matched == 0:
+		case bankImportInput.apply:
+			output.PrintSuccess(fmt.Sprintf("%d payments registered on %s", matched, accountCode))
+		default:
+			output.PrintInfo("Run again with --apply to register the matched payments.")
+		}
+		return nil
+	},
+}
+
+// fetchReconcileDocs fetches the unpaid sales when the statement has money
+// in, and the unpaid purchases when it has money out. Purchases are only
+// fetched from reconcileLookbackDays before the first line up to the last,
+// since a purchase cannot be paid before it is dated.
+func fetchReconcileDocs(client *api.Client, slug string, lines []statementLine) ([]*reconcileDoc, error) {
+	var in, out bool
+	var first, last string
+	for _, l := range lines {
+		in = in || l.Amount > 0
+		out = out || l.Amount < 0
+		if first == "" || l.Date < first {
+			first = l.Date
+		}
+		if l.Date > last {
+			last = l.Date
+		}
+	}
+
+	var docs []*reconcileDoc
+	if in {
+		params := url.Values{}
+		params.Set("settled", "false")
+		sales, err := fetchPages[api.Sale](client, fmt.Sprintf(api.EndpointSales, slug), params, api.MaxPageSize, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fetching sales: %w", err)
+		}
+		for _, s := range sales {
+			outstanding := s.OutstandingBalance
+			if outstanding == 0 {
+				outstanding = saleGross(s) - s.TotalPaid
+			}
+			if s.Deleted || outstanding <= 0 {
+				continue
+			}
+			docs = append(docs, &reconcileDoc{
+				kind:        "sale",
+				id:          s.SaleId,
+				number:      s.SaleNumber,
+				date:        s.Date,
+				dueDate:     s.DueDate,
+				kid:         s.Kid,
+				contact:     s.Customer.Name,
+				outstanding: outstanding,
+				currency:    s.Currency,
+			})
+		}
+	}
+	if out {
+		params := url.Values{}
+		if from, err := time.Parse("2006-01-02", first); err == nil {
+			params.Set("dateGe", from.AddDate(0, 0, -reconcileLookbackDays).Format("2006-01-02"))
+		}
+		if validateDate(last) == nil {
+			params.Set("dateLe", last)
+		}
+		purchases, err := fetchPages[api.Purchase](client, fmt.Sprintf(api.EndpointPurchases, slug), params, api.MaxPageSize, 0)
+		if err != nil {
+			return nil, fmt.Errorf("fetching purchases: %w", err)
+		}
+		for _, p := range purchases {
+			outstanding := purchaseGross(p) - p.TotalPaid
+			if p.Paid || p.Deleted || outstanding <= 0 {
+				continue
+			}
+			docs = append(docs, &reconcileDoc{
+				kind:        "purchase",
+				id:          p.PurchaseId,
+				number:      p.Identifier,
+				date:        p.Date,
+				dueDate:     p.DueDate,
+				kid:         p.Kid,
+				contact:     p.Supplier.Name,
+				outstanding: outstanding,
+				currency:    p.Currency,
+			})
+		}
+	}
+	return docs, nil
+}
+
+// matchStatementLine finds the document a statement line pays and marks
+// it as matched. Money in is matched against sales and money out against
+// purchases, in the line's currency; a line without one is in
+// accountCurrency. Documents without a currency are in NOK.
+func matchStatementLine(line statementLine, accountCurrency string, docs []*reconcileDoc) reconcileMatch {
+	if line.Currency == "" {
+		line.Currency = accountCurrency
+	}
+	m := reconcileMatch{Line: line}
+	if line.Amount == 0 {
+		return m
+	}
+	if line.Currency == "" {
+		m.Note = "unknown currency, use --currency"
+		return m
+	}
+
+	kind, value := "sale", line.Amount
+	if line.Amount < 0 {
+		kind, value = "purchase", -line.Amount
+	}
+
+	var best []*reconcileDoc
+	var bestScore int
+	var bestBasis string
+	var amountOnly []*reconcileDoc
+	for _, d := range docs {
+		if d.matched || d.kind != kind {
+			continue
+		}
+		currency := d.currency
+		if currency == "" {
+			currency = "NOK"
+		}
+		if !strings.EqualFold(line.Currency, currency) {
+			continue
+		}
+
+		kid := d.kid != "" && (line.Reference == d.kid || containsNumber(line.Text, d.kid))
+		amount := value == d.outstanding
+		if kid && value > d.outstanding {
+			// More than is owed; leave overpayments for manual handling.
+			kid = false
+		}
+		if !kid && !amount {
+			continue
+		}
+		name := namesMatch(d.contact, line.Counterparty+" "+line.Text)
+		date := datesMatch(line.Date, d.date, d.dueDate)
+
+		score := 0
+		var basis []string
+		if kid {
+			score += 8
+			basis = append(basis, "KID")
+		}
+		if amount {
+			score += 4
+			basis = append(basis, "amount")
+		}
+		if name {
+			score += 2
+			basis = append(basis, "name")
+		}
+		if date {
+			score++
+			basis = append(basis, "date")
+		}
+		if !kid && !name && !date {
+			amountOnly = append(amountOnly, d)
+			continue
+		}
+
+		switch {
+		case score > bestScore:
+			best, bestScore, bestBasis = []*reconcileDoc{d}, score, strings.Join(basis, ", ")
+		case score == bestScore:
+			best = append(best, d)
+		}
+	}
+
+	switch {
+	case len(best) == 1:
+		d := best[0]
+		d.matched = true
+		m.Kind = d.kind
+		m.ID = d.id
+		m.Number = d.number
+		m.Amount = value
+		m.Basis = bestBasis
+	case len(best) > 1:
+		m.Note = fmt.Sprintf("%d possible %ss", len(best), kind)
+	case len(amountOnly) == 1:
+		m.Note = fmt.Sprintf("amount only: %s %s (ID %d)", kind, amountOnly[0].number, amountOnly[0].id)
+	case len(amountOnly) > 1:
+		m.Note = fmt.Sprintf("amount only: %d %ss", len(amountOnly), kind)
+	}
+	return m
+}
+
+// containsNumber reports whether number appears in s as a whole number,
+// not as part of a longer one.
+func containsNumber(s, number string) bool {
+	fields := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsDigit(r) })
+	for _, f := range fields {
+		if f == number {
+			return true
+		}
+	}
+	return false
+}
+
+// namesMatch reports whether a contact name appears in a statement text,
+// ignoring case and company suffixes such as AS.
+func namesMatch(contact, text string) bool {
+	contact = strings.ToLower(strings.TrimSpace(contact))
+	for _, suffix := range []string{" asa", " as", " ans", " da", " ab"} {
+		contact = strings.TrimSuffix(contact, suffix)
+	}
+	if len(contact) < 3 {
+		return false
+	}
+	return strings.Contains(strings.ToLower(text), contact)
+}
+
+// datesMatch reports whether a payment date fits a document: on or after
+// the document date, and within 30 days of the due date if it has one.
+func datesMatch(paid, date, dueDate string) bool {
+	p, err := time.Parse("2006-01-02", paid)
+	if err != nil {
+		return false
+	}
+	if d, err := time.Parse("2006-01-02", date); err == nil && p.Before(d) {
+		return false
+	}
+	if dueDate == "" {
+		return true
+	}
+	due, err := time.Parse("2006-01-02", dueDate)
+	if err != nil {
+		return true
+	}
+	days := p.Sub(due).Hours() / 24
+	return days >= -30 && days <= 30
+}
+
+// mergeCSVFormat copies the non-empty fields of flags into f.
+func mergeCSVFormat(f *csvFormat, flags csvFormat) {
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&f.Delimiter, flags.Delimiter},
+		{&f.DateFormat, flags.DateFormat},
+		{&f.Date, flags.Date},
+		{&f.Amount, flags.Amount},
+		{&f.In, flags.In},
+		{&f.Out, flags.Out},
+		{&f.Text, flags.Text},
+		{&f.Reference, flags.Reference},
+		{&f.Counterparty, flags.Counterparty},
+	} {
+		if field.src != "" {
+			*field.dst = field.src
+		}
+	}
+}
+
+// shorten cuts s to at most n runes, marking the cut with "...".
+func shorten(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-3]) + "..."
+}
+
+func init() {
+	f := bankImportCmd.Flags()
+	f.StringVar(&bankImportInput.account, "account", "", "Bank account code, ID, number or name (default the only active one)")
+	f.StringVar(&bankImportInput.currency, "currency", "", "Currency of the bank account (default NOK, required for foreign accounts)")
+	f.StringVar(&bankImportInput.format, "format", "", "Statement format: camt or csv (default from the file extension)")
+	f.BoolVar(&bankImportInput.apply, "apply", false, "Register the matched payments")
+	f.StringVar(&bankImportInput.csvConfig, "csv-config", "", "JSON or YAML file describing the CSV layout")
+	f.StringVar(&bankImportInput.csv.Delimiter, "csv-delimiter", "", "CSV field delimiter (default ;)")
+	f.StringVar(&bankImportInput.csv.DateFormat, "csv-date-format", "", "CSV date layout (default 02.01.2006)")
+	f.StringVar(&bankImportInput.csv.Date, "csv-date", "", "CSV date column")
+	f.StringVar(&bankImportInput.csv.Amount, "csv-amount", "", "CSV signed amount column")
+	f.StringVar(&bankImportInput.csv.In, "csv-in", "", "CSV money in column")
+	f.StringVar(&bankImportInput.csv.Out, "csv-out", "", "CSV money out column")
+	f.StringVar(&bankImportInput.csv.Text, "csv-text", "", "CSV description column")
+	f.StringVar(&bankImportInput.csv.Reference, "csv-reference", "", "CSV KID/reference column")
+	f.StringVar(&bankImportInput.csv.Counterparty, "csv-counterparty", "", "CSV counterparty column")
+
+	bankCmd.AddCommand(bankImportCmd)
+}
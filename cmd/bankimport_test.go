@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+func TestMatchStatementLine(t *testing.T) {
+	newDocs := func() []*reconcileDoc {
+		return []*reconcileDoc{
+			{kind: "sale", id: 1, number: "10001", date: "2024-02-01", dueDate: "2024-02-15", kid: "0000012345678", contact: "Acme AS", outstanding: 125000},
+			{kind: "sale", id: 2, number: "10002", date: "2024-02-01", dueDate: "2024-02-15", contact: "Nordic Supplies AS", outstanding: 50000, currency: "NOK"},
+			{kind: "sale", id: 3, number: "10003", date: "2024-02-01", dueDate: "2024-02-15", contact: "Twin AS", outstanding: 70000},
+			{kind: "sale", id: 4, number: "10004", date: "2024-02-01", dueDate: "2024-02-15", contact: "Twin AS", outstanding: 70000},
+			{kind: "sale", id: 5, number: "10005", date: "2024-02-01", dueDate: "2024-02-15", contact: "Euro GmbH", outstanding: 10000, currency: "EUR"},
+			{kind: "purchase", id: 6, number: "881", date: "2024-02-20", dueDate: "2024-03-05", contact: "Kontor AS", outstanding: 9950},
+			{kind: "sale", id: 7, number: "10007", date: "2023-01-01", dueDate: "2023-01-15", contact: "Old AS", outstanding: 33300},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		line      statementLine
+		currency  string
+		wantID    int64
+		wantBasis string
+		wantNote  string
+	}{
+		{
+			name:      "KID",
+			line:      statementLine{Date: "2024-02-14", Amount: 125000, Reference: "0000012345678"},
+			currency:  "NOK",
+			wantID:    1,
+			wantBasis: "KID, amount, date",
+		},
+		{
+			name:      "KID in text, partial payment",
+			line:      statementLine{Date: "2024-05-01", Amount: 100000, Text: "Betaling KID 0000012345678"},
+			currency:  "NOK",
+			wantID:    1,
+			wantBasis: "KID",
+		},
+		{
+			name:     "KID overpayment",
+			line:     statementLine{Date: "2024-02-14", Amount: 130000, Reference: "0000012345678"},
+			currency: "NOK",
+		},
+		{
+			name:      "amount and name",
+			line:      statementLine{Date: "2024-06-01", Amount: 50000, Counterparty: "NORDIC SUPPLIES"},
+			currency:  "NOK",
+			wantID:    2,
+			wantBasis: "amount, name",
+		},
+		{
+			name:     "ambiguous",
+			line:     statementLine{Date: "2024-02-10", Amount: 70000, Counterparty: "Twin"},
+			currency: "NOK",
+			wantNote: "2 possible sales",
+		},
+		{
+			name:     "amount only",
+			line:     statementLine{Date: "2024-06-01", Amount: 33300},
+			currency: "NOK",
+			wantNote: "amount only: sale 10007 (ID 7)",
+		},
+		{
+			name:      "purchase",
+			line:      statementLine{Date: "2024-03-01", Amount: -9950, Counterparty: "Kontor AS"},
+			currency:  "NOK",
+			wantID:    6,
+			wantBasis: "amount, name, date",
+		},
+		{
+			name:      "line currency",
+			line:      statementLine{Date: "2024-02-10", Amount: 10000, Currency: "EUR", Counterparty: "Euro GmbH"},
+			currency:  "NOK",
+			wantID:    5,
+			wantBasis: "amount, name, date",
+		},
+		{
+			name:      "account currency",
+			line:      statementLine{Date: "2024-02-10", Amount: 10000, Counterparty: "Euro GmbH"},
+			currency:  "EUR",
+			wantID:    5,
+			wantBasis: "amount, name, date",
+		},
+		{
+			name:     "currency mismatch",
+			line:     statementLine{Date: "2024-02-10", Amount: 10000, Counterparty: "Euro GmbH"},
+			currency: "NOK",
+		},
+		{
+			name:     "documents without currency are NOK",
+			line:     statementLine{Date: "2024-02-14", Amount: 125000, Currency: "EUR", Reference: "0000012345678"},
+			currency: "EUR",
+		},
+		{
+			name:     "unknown currency",
+			line:     statementLine{Date: "2024-02-14", Amount: 125000, Reference: "0000012345678"},
+			wantNote: "unknown currency",
+		},
+		{
+			name:     "zero amount",
+			line:     statementLine{Date: "2024-02-14"},
+			currency: "NOK",
+		},
+	}
+	for _, tt := range tests {
+		docs := newDocs()
+		m := matchStatementLine(tt.line, tt.currency, docs)
+		if m.ID != tt.wantID || m.Basis != tt.wantBasis || !strings.HasPrefix(m.Note, tt.wantNote) {
+			t.Errorf("%s: got ID %d basis %q note %q, want ID %d basis %q note %q",
+				tt.name, m.ID, m.Basis, m.Note, tt.wantID, tt.wantBasis, tt.wantNote)
+		}
+		if m.Line.Currency == "" && tt.currency != "" {
+			t.Errorf("%s: line currency not defaulted to %s", tt.name, tt.currency)
+		}
+		if m.ID != 0 {
+			for _, d := range docs {
+				if d.matched != (d.id == m.ID) {
+					t.Errorf("%s: %s %d matched = %v", tt.name, d.kind, d.id, d.matched)
+				}
+			}
+		}
+	}
+}
+
+func TestMatchStatementLineOnce(t *testing.T) {
+	docs := []*reconcileDoc{
+		{kind: "sale", id: 1, number: "1", kid: "123", outstanding: 100},
+	}
+	line := statementLine{Date: "2024-01-01", Amount: 100, Reference: "123"}
+	if m := matchStatementLine(line, "NOK", docs); m.ID != 1 {
+		t.Fatalf("first match = %d, want 1", m.ID)
+	}
+	if m := matchStatementLine(line, "NOK", docs); m.ID != 0 {
+		t.Errorf("second match = %d, want none", m.ID)
+	}
+}
+
+func TestFetchReconcileDocsPurchaseWindow(t *testing.T) {
+	var query map[string]string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/companies/acme/purchases" {
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		query = map[string]string{"dateGe": q.Get("dateGe"), "dateLe": q.Get("dateLe")}
+		writeJSON(t, w, []api.Purchase{})
+	}))
+
+	lines := []statementLine{
+		{Date: "2024-03-10", Amount: -100},
+		{Date: "2024-03-01", Amount: -200},
+		{Date: "2024-03-31", Amount: -300},
+	}
+	if _, err := fetchReconcileDocs(client, "acme", lines); err != nil {
+		t.Fatal(err)
+	}
+	if query["dateGe"] != "2023-12-02" || query["dateLe"] != "2024-03-31" {
+		t.Errorf("purchases fetched with dateGe=%s dateLe=%s, want 2023-12-02 and 2024-03-31", query["dateGe"], query["dateLe"])
+	}
+}
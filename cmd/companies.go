@@ -19,8 +19,7 @@ var companiesCmd = &cobra.Command{
 			return err
 		}
 
-		var companies []api.Company
-		_, err = client.Get(api.EndpointCompanies, &companies)
+		companies, err := fetchPages[api.Company](client, api.EndpointCompanies, nil, api.MaxPageSize, 0)
 		if err != nil {
 			return fmt.Errorf("fetching companies: %w", err)
 		}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+// contactPersonInput holds the flags shared by persons add and update.
+var contactPersonInput struct {
+	name  string
+	email string
+	phone string
+}
+
+var contactPersonsCmd = &cobra.Command{
+	Use:   "persons",
+	Short: "Manage contact persons",
+	Long:  "List and manage the contact persons of a customer or supplier.",
+}
+
+var contactPersonsListCmd = &cobra.Command{
+	Use:   "list <contactId>",
+	Short: "List contact persons",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		persons, err := fetchPages[api.ContactPerson](client, fmt.Sprintf(api.EndpointContactPersons, slug, contactID), nil, api.MaxPageSize, 0)
+		if err != nil {
+			return fmt.Errorf("fetching contact persons: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(persons)
+		}
+
+		if len(persons) == 0 {
+			output.PrintInfo("No contact persons found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NAME", "EMAIL", "PHONE")
+		for _, p := range persons {
+			table.AddRow(
+				fmt.Sprintf("%d", p.ContactPersonId),
+				p.Name,
+				p.Email,
+				p.PhoneNumber,
+			)
+		}
+		table.Print()
+
+		return nil
+	},
+}
+
+var contactPersonsAddCmd = &cobra.Command{
+	Use:     "add <contactId>",
+	Short:   "Add a contact person",
+	Args:    cobra.ExactArgs(1),
+	Example: `  fiken contacts persons add 123 --name "Ola Nordmann" --email faktura@acme.no`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+		if contactPersonInput.name == "" || contactPersonInput.email == "" {
+			return fmt.Errorf("--name and --email are required")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		req := api.ContactPersonRequest{
+			Name:        contactPersonInput.name,
+			Email:       contactPersonInput.email,
+			PhoneNumber: contactPersonInput.phone,
+		}
+
+		_, err = client.Post(fmt.Sprintf(api.EndpointContactPersons, slug, contactID), req, nil)
+		if err != nil {
+			return fmt.Errorf("adding contact person: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Contact person '%s' added to contact %d", req.Name, contactID))
+		return nil
+	},
+}
+
+var contactPersonsUpdateCmd = &cobra.Command{
+	Use:   "update <contactId> <contactPersonId>",
+	Short: "Update a contact person",
+	Long:  "Update a contact person. Only the fields given as flags are changed.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+		personID, err := parseID(args[1], "contact person")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointContactPerson, slug, contactID, personID)
+
+		var person api.ContactPerson
+		_, err = client.Get(endpoint, &person)
+		if err != nil {
+			return fmt.Errorf("fetching contact person: %w", err)
+		}
+
+		req := api.ContactPersonRequest{
+			Name:        person.Name,
+			Email:       person.Email,
+			PhoneNumber: person.PhoneNumber,
+			Address:     person.Address,
+		}
+		if cmd.Flags().Changed("name") {
+			req.Name = contactPersonInput.name
+		}
+		if cmd.Flags().Changed("email") {
+			req.Email = contactPersonInput.email
+		}
+		if cmd.Flags().Changed("phone") {
+			req.PhoneNumber = contactPersonInput.phone
+		}
+
+		if err := client.Put(endpoint, req, nil); err != nil {
+			return fmt.Errorf("updating contact person: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Contact person %d updated", personID))
+		return nil
+	},
+}
+
+var contactPersonsRemoveCmd = &cobra.Command{
+	Use:   "remove <contactId> <contactPersonId>",
+	Short: "Remove a contact person",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+		personID, err := parseID(args[1], "contact person")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Remove contact person %d from contact %d?", personID, contactID)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		err = client.Delete(fmt.Sprintf(api.EndpointContactPerson, slug, contactID, personID))
+		if err != nil {
+			return fmt.Errorf("removing contact person: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Contact person %d removed", personID))
+		return nil
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{contactPersonsAddCmd, contactPersonsUpdateCmd} {
+		c.Flags().StringVar(&contactPersonInput.name, "name", "", "Name")
+		c.Flags().StringVar(&contactPersonInput.email, "email", "", "Email address")
+		c.Flags().StringVar(&contactPersonInput.phone, "phone", "", "Phone number")
+	}
+
+	contactPersonsCmd.AddCommand(contactPersonsListCmd)
+	contactPersonsCmd.AddCommand(contactPersonsAddCmd)
+	contactPersonsCmd.AddCommand(contactPersonsUpdateCmd)
+	contactPersonsCmd.AddCommand(contactPersonsRemoveCmd)
+	contactsCmd.AddCommand(contactPersonsCmd)
+}
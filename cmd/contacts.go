@@ -2,25 +2,483 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	contactsCustomer  bool
+	contactsSupplier  bool
+	contactsInactive  bool
+	contactsName      string
+	contactsEmail     string
+	contactsOrgNumber string
+	contactsLimit     int
+	contactsPageSize  int
+)
+
+// contactInput holds the flags shared by contacts create and update.
+var contactInput struct {
+	name      string
+	email     string
+	orgNumber string
+	phone     string
+	customer  bool
+	supplier  bool
+	street    string
+	postCode  string
+	city      string
+	country   string
+	language  string
+	currency  string
+	dueDays   int
+	inactive  bool
+}
+
 var contactsCmd = &cobra.Command{
 	Use:   "contacts",
 	Short: "Manage contacts",
+	Long:  "List, search and manage customers and suppliers.",
 }
 
 var contactsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List contacts",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Not yet implemented.")
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if cmd.Flags().Changed("customer") {
+			params.Set("customer", strconv.FormatBool(contactsCustomer))
+		}
+		if cmd.Flags().Changed("supplier") {
+			params.Set("supplier", strconv.FormatBool(contactsSupplier))
+		}
+		if cmd.Flags().Changed("inactive") {
+			params.Set("inactive", strconv.FormatBool(contactsInactive))
+		}
+		if contactsName != "" {
+			params.Set("name", contactsName)
+		}
+		if contactsEmail != "" {
+			params.Set("email", contactsEmail)
+		}
+		if contactsOrgNumber != "" {
+			params.Set("organizationNumber", contactsOrgNumber)
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointContacts, slug)
+
+		contacts, err := fetchPages[api.Contact](client, endpoint, params, contactsPageSize, contactsLimit)
+		if err != nil {
+			return fmt.Errorf("fetching contacts: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(contacts)
+		}
+
+		if len(contacts) == 0 {
+			output.PrintInfo("No contacts found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NAME", "EMAIL", "ORG.NR", "CUSTOMER", "SUPPLIER", "ACTIVE")
+		for _, c := range contacts {
+			table.AddRow(
+				fmt.Sprintf("%d", c.ContactId),
+				c.Name,
+				c.Email,
+				c.OrganizationNumber,
+				yesNo(c.Customer),
+				yesNo(c.Supplier),
+				yesNo(!c.Inactive),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d contacts\n", len(contacts))
+		return nil
+	},
+}
+
+var contactsGetCmd = &cobra.Command{
+	Use:   "get <contactId>",
+	Short: "Show a contact",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var contact api.Contact
+		_, err = client.Get(fmt.Sprintf(api.EndpointContact, slug, contactID), &contact)
+		if err != nil {
+			return fmt.Errorf("fetching contact: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(contact)
+		}
+
+		printContact(contact)
+		return nil
+	},
+}
+
+var contactsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a contact",
+	Example: `  fiken contacts create --name "Acme AS" --org-number 999999999 --supplier
+  fiken contacts create --name "Kari Nordmann" --email kari@example.no --customer`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if contactInput.name == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		req := api.ContactRequest{}
+		applyContactFlags(cmd, &req)
+
+		contactID, err := client.PostForID(fmt.Sprintf(api.EndpointContacts, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating contact: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"contactId": contactID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Contact '%s' created (ID %d)", req.Name, contactID))
+		return nil
+	},
+}
+
+var contactsUpdateCmd = &cobra.Command{
+	Use:   "update <contactId>",
+	Short: "Update a contact",
+	Long:  "Update a contact. Only the fields given as flags are changed.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointContact, slug, contactID)
+
+		// Fiken replaces the whole contact on update, so start from the current one.
+		var contact api.Contact
+		_, err = client.Get(endpoint, &contact)
+		if err != nil {
+			return fmt.Errorf("fetching contact: %w", err)
+		}
+
+		req := contactToRequest(contact)
+		applyContactFlags(cmd, &req)
+
+		if err := client.Put(endpoint, req, nil); err != nil {
+			return fmt.Errorf("updating contact: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Contact %d updated", contactID))
 		return nil
 	},
 }
 
+var contactsDeleteCmd = &cobra.Command{
+	Use:   "delete <contactId>",
+	Short: "Delete a contact",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		contactID, err := parseID(args[0], "contact")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Delete contact %d?", contactID)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		if err := client.Delete(fmt.Sprintf(api.EndpointContact, slug, contactID)); err != nil {
+			return fmt.Errorf("deleting contact: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Contact %d deleted", contactID))
+		return nil
+	},
+}
+
+// resolveContact looks up a contact by numeric ID or by name.
+// A name must match exactly one contact, ignoring case.
+func resolveContact(client *api.Client, slug, ref string) (api.Contact, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return api.Contact{}, fmt.Errorf("no contact given")
+	}
+
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		var contact api.Contact
+		_, err := client.Get(fmt.Sprintf(api.EndpointContact, slug, id), &contact)
+		if err != nil {
+			return api.Contact{}, fmt.Errorf("fetching contact %d: %w", id, err)
+		}
+		return contact, nil
+	}
+
+	params := url.Values{"name": {ref}}
+	matches, err := fetchPages[api.Contact](client, fmt.Sprintf(api.EndpointContacts, slug), params, api.MaxPageSize, api.MaxPageSize)
+	if err != nil {
+		return api.Contact{}, fmt.Errorf("searching contacts: %w", err)
+	}
+
+	var exact []api.Contact
+	for _, c := range matches {
+		if strings.EqualFold(c.Name, ref) {
+			exact = append(exact, c)
+		}
+	}
+	if len(exact) == 1 {
+		return exact[0], nil
+	}
+	if len(exact) == 0 && len(matches) == 1 {
+		return matches[0], nil
+	}
+	if len(exact) > 1 {
+		matches = exact
+	}
+
+	if len(matches) == 0 {
+		return api.Contact{}, fmt.Errorf("no contact found matching %q", ref)
+	}
+	names := make([]string, len(matches))
+	for i, c := range matches {
+		names[i] = fmt.Sprintf("  %s (%d)", c.Name, c.ContactId)
+	}
+	return api.Contact{}, fmt.Errorf("multiple contacts match %q. Use the contact ID instead:\n%s", ref, strings.Join(names, "\n"))
+}
+
+// contactToRequest converts a fetched contact into an update request.
+func contactToRequest(c api.Contact) api.ContactRequest {
+	req := api.ContactRequest{
+		Name:                      c.Name,
+		Email:                     c.Email,
+		OrganizationNumber:        c.OrganizationNumber,
+		PhoneNumber:               c.PhoneNumber,
+		MemberNumber:              c.MemberNumber,
+		Customer:                  c.Customer,
+		Supplier:                  c.Supplier,
+		Language:                  c.Language,
+		Currency:                  c.Currency,
+		DaysUntilInvoicingDueDate: c.DaysUntilInvoicingDueDate,
+		Inactive:                  c.Inactive,
+	}
+	if c.Address != (api.Address{}) {
+		address := c.Address
+		req.Address = &address
+	}
+	return req
+}
+
+// applyContactFlags copies the contact flags that were set on cmd into req.
+func applyContactFlags(cmd *cobra.Command, req *api.ContactRequest) {
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		req.Name = contactInput.name
+	}
+	if flags.Changed("email") {
+		req.Email = contactInput.email
+	}
+	if flags.Changed("org-number") {
+		req.OrganizationNumber = contactInput.orgNumber
+	}
+	if flags.Changed("phone") {
+		req.PhoneNumber = contactInput.phone
+	}
+	if flags.Changed("customer") {
+		req.Customer = contactInput.customer
+	}
+	if flags.Changed("supplier") {
+		req.Supplier = contactInput.supplier
+	}
+	if flags.Changed("language") {
+		req.Language = contactInput.language
+	}
+	if flags.Changed("currency") {
+		req.Currency = contactInput.currency
+	}
+	if flags.Changed("due-days") {
+		req.DaysUntilInvoicingDueDate = contactInput.dueDays
+	}
+	if flags.Changed("inactive") {
+		req.Inactive = contactInput.inactive
+	}
+
+	if flags.Changed("street") || flags.Changed("post-code") || flags.Changed("city") || flags.Changed("country") {
+		if req.Address == nil {
+			req.Address = &api.Address{}
+		}
+		if flags.Changed("street") {
+			req.Address.StreetAddress = contactInput.street
+		}
+		if flags.Changed("post-code") {
+			req.Address.PostCode = contactInput.postCode
+		}
+		if flags.Changed("city") {
+			req.Address.City = contactInput.city
+		}
+		if flags.Changed("country") {
+			req.Address.Country = contactInput.country
+		}
+	}
+}
+
+// addContactFlags registers the flags shared by contacts create and update.
+func addContactFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&contactInput.name, "name", "", "Contact name")
+	cmd.Flags().StringVar(&contactInput.email, "email", "", "Email address")
+	cmd.Flags().StringVar(&contactInput.orgNumber, "org-number", "", "Organization number")
+	cmd.Flags().StringVar(&contactInput.phone, "phone", "", "Phone number")
+	cmd.Flags().BoolVar(&contactInput.customer, "customer", false, "Mark as customer")
+	cmd.Flags().BoolVar(&contactInput.supplier, "supplier", false, "Mark as supplier")
+	cmd.Flags().StringVar(&contactInput.street, "street", "", "Street address")
+	cmd.Flags().StringVar(&contactInput.postCode, "post-code", "", "Post code")
+	cmd.Flags().StringVar(&contactInput.city, "city", "", "City")
+	cmd.Flags().StringVar(&contactInput.country, "country", "", "Country")
+	cmd.Flags().StringVar(&contactInput.language, "language", "", "Language for documents (Norwegian, English)")
+	cmd.Flags().StringVar(&contactInput.currency, "currency", "", "Default currency (e.g. NOK)")
+	cmd.Flags().IntVar(&contactInput.dueDays, "due-days", 0, "Days until invoice due date")
+	cmd.Flags().BoolVar(&contactInput.inactive, "inactive", false, "Mark as inactive")
+}
+
+func printContact(c api.Contact) {
+	d := output.NewDetails()
+	d.Add("ID", fmt.Sprintf("%d", c.ContactId))
+	d.Add("Name", c.Name)
+	d.Add("Email", c.Email)
+	d.Add("Phone", c.PhoneNumber)
+	d.Add("Org.nr", c.OrganizationNumber)
+	d.Add("Customer", yesNo(c.Customer))
+	if c.CustomerNumber != 0 {
+		d.Add("Customer no.", fmt.Sprintf("%d", c.CustomerNumber))
+	}
+	d.Add("Supplier", yesNo(c.Supplier))
+	if c.SupplierNumber != 0 {
+		d.Add("Supplier no.", fmt.Sprintf("%d", c.SupplierNumber))
+	}
+	d.Add("Address", formatAddress(c.Address))
+	d.Add("Language", c.Language)
+	d.Add("Currency", c.Currency)
+	if c.DaysUntilInvoicingDueDate != 0 {
+		d.Add("Due days", fmt.Sprintf("%d", c.DaysUntilInvoicingDueDate))
+	}
+	d.Add("Active", yesNo(!c.Inactive))
+	d.Print()
+
+	if len(c.ContactPersons) > 0 {
+		fmt.Println()
+		table := output.NewTable("PERSON ID", "NAME", "EMAIL", "PHONE")
+		for _, p := range c.ContactPersons {
+			table.AddRow(fmt.Sprintf("%d", p.ContactPersonId), p.Name, p.Email, p.PhoneNumber)
+		}
+		table.Print()
+	}
+}
+
+func formatAddress(a api.Address) string {
+	s := a.StreetAddress
+	if a.StreetAddressLine2 != "" {
+		s += ", " + a.StreetAddressLine2
+	}
+	place := a.PostCode
+	if a.City != "" {
+		if place != "" {
+			place += " "
+		}
+		place += a.City
+	}
+	for _, part := range []string{place, a.Country} {
+		if part == "" {
+			continue
+		}
+		if s != "" {
+			s += ", "
+		}
+		s += part
+	}
+	return s
+}
+
 func init() {
+	contactsListCmd.Flags().BoolVar(&contactsCustomer, "customer", false, "Only customers (--customer=false to exclude)")
+	contactsListCmd.Flags().BoolVar(&contactsSupplier, "supplier", false, "Only suppliers (--supplier=false to exclude)")
+	contactsListCmd.Flags().BoolVar(&contactsInactive, "inactive", false, "Only inactive contacts (--inactive=false for active)")
+	contactsListCmd.Flags().StringVar(&contactsName, "name", "", "Search by name")
+	contactsListCmd.Flags().StringVar(&contactsEmail, "email", "", "Search by email")
+	contactsListCmd.Flags().StringVar(&contactsOrgNumber, "org-number", "", "Search by organization number")
+	contactsListCmd.Flags().IntVar(&contactsLimit, "limit", 0, "Maximum number of contacts to show (0 = all)")
+	contactsListCmd.Flags().IntVar(&contactsPageSize, "page-size", api.MaxPageSize, "Number of contacts to fetch per request")
+
+	addContactFlags(contactsCreateCmd)
+	addContactFlags(contactsUpdateCmd)
+
 	contactsCmd.AddCommand(contactsListCmd)
+	contactsCmd.AddCommand(contactsGetCmd)
+	contactsCmd.AddCommand(contactsCreateCmd)
+	contactsCmd.AddCommand(contactsUpdateCmd)
+	contactsCmd.AddCommand(contactsDeleteCmd)
 	rootCmd.AddCommand(contactsCmd)
 }
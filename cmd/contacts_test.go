@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+func TestResolveContact(t *testing.T) {
+	contacts := []api.Contact{
+		{ContactId: 1, Name: "Acme AS"},
+		{ContactId: 2, Name: "Acme AS Oslo"},
+		{ContactId: 3, Name: "Nordic Supplies"},
+		{ContactId: 4, Name: "Twin"},
+		{ContactId: 5, Name: "twin"},
+	}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/companies/acme/contacts/3":
+			writeJSON(t, w, contacts[2])
+		case "/companies/acme/contacts":
+			// Fiken matches names by substring, ignoring case.
+			name := strings.ToLower(r.URL.Query().Get("name"))
+			matches := []api.Contact{}
+			for _, c := range contacts {
+				if strings.Contains(strings.ToLower(c.Name), name) {
+					matches = append(matches, c)
+				}
+			}
+			writeJSON(t, w, matches)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	tests := []struct {
+		ref     string
+		want    int64
+		wantErr string
+	}{
+		{ref: "3", want: 3},
+		{ref: "acme as", want: 1},
+		{ref: "Nordic", want: 3},
+		{ref: " Nordic Supplies ", want: 3},
+		{ref: "99", wantErr: "fetching contact 99"},
+		{ref: "Acme", wantErr: "multiple contacts match"},
+		{ref: "twin", wantErr: "multiple contacts match"},
+		{ref: "Nobody", wantErr: "no contact found"},
+		{ref: "", wantErr: "no contact given"},
+	}
+	for _, tt := range tests {
+		got, err := resolveContact(client, "acme", tt.ref)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("resolveContact(%q) error = %v, want %q", tt.ref, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("resolveContact(%q) error: %v", tt.ref, err)
+			continue
+		}
+		if got.ContactId != tt.want {
+			t.Errorf("resolveContact(%q) = contact %d, want %d", tt.ref, got.ContactId, tt.want)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	creditNotesFrom     string
+	creditNotesTo       string
+	creditNotesCustomer int64
+	creditNotesSettled  bool
+	creditNotesLimit    int
+	creditNotesPageSize int
+)
+
+// invoiceCreditInput holds the flags for invoices credit.
+var invoiceCreditInput struct {
+	lines  []int
+	amount string
+	date   string
+	text   string
+	send   string
+}
+
+var creditNotesCmd = &cobra.Command{
+	Use:   "creditnotes",
+	Short: "List credit notes",
+	Long: `List and inspect credit notes.
+
+Credit notes are created from an invoice with 'fiken invoices credit'.`,
+}
+
+var creditNotesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List credit notes",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if creditNotesFrom != "" {
+			if err := validateDate(creditNotesFrom); err != nil {
+				return err
+			}
+			params.Set("issueDateGe", creditNotesFrom)
+		}
+		if creditNotesTo != "" {
+			if err := validateDate(creditNotesTo); err != nil {
+				return err
+			}
+			params.Set("issueDateLe", creditNotesTo)
+		}
+		if creditNotesCustomer != 0 {
+			params.Set("customerId", strconv.FormatInt(creditNotesCustomer, 10))
+		}
+		if cmd.Flags().Changed("settled") {
+			params.Set("settled", strconv.FormatBool(creditNotesSettled))
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointCreditNotes, slug)
+
+		creditNotes, err := fetchPages[api.CreditNote](client, endpoint, params, creditNotesPageSize, creditNotesLimit)
+		if err != nil {
+			return fmt.Errorf("fetching credit notes: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(creditNotes)
+		}
+
+		if len(creditNotes) == 0 {
+			output.PrintInfo("No credit notes found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "ISSUED", "CUSTOMER", "INVOICE ID", "GROSS", "SETTLED")
+		for _, cn := range creditNotes {
+			table.AddRow(
+				fmt.Sprintf("%d", cn.CreditNoteId),
+				fmt.Sprintf("%d", cn.CreditNoteNumber),
+				cn.IssueDate,
+				cn.Customer.Name,
+				fmt.Sprintf("%d", cn.AssociatedInvoiceId),
+				output.FormatAmount(cn.Gross),
+				yesNo(cn.Settled),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d credit notes\n", len(creditNotes))
+		return nil
+	},
+}
+
+var creditNotesGetCmd = &cobra.Command{
+	Use:   "get <creditNoteId>",
+	Short: "Show a credit note",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		creditNoteID, err := parseID(args[0], "credit note")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var cn api.CreditNote
+		_, err = client.Get(fmt.Sprintf(api.EndpointCreditNote, slug, creditNoteID), &cn)
+		if err != nil {
+			return fmt.Errorf("fetching credit note: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(cn)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", cn.CreditNoteId))
+		d.Add("Number", fmt.Sprintf("%d", cn.CreditNoteNumber))
+		d.Add("Customer", cn.Customer.Name)
+		d.Add("Issue date", cn.IssueDate)
+		if cn.AssociatedInvoiceId != 0 {
+			d.Add("Invoice ID", fmt.Sprintf("%d", cn.AssociatedInvoiceId))
+		}
+		d.Add("KID", cn.Kid)
+		d.Add("Currency", cn.Currency)
+		d.Add("Settled", yesNo(cn.Settled))
+		d.Add("Text", cn.CreditNoteText)
+		d.Print()
+
+		fmt.Println()
+		table := output.NewTable("DESCRIPTION", "QTY", "UNIT PRICE", "NET", "VAT", "GROSS", "VAT TYPE")
+		for _, l := range cn.Lines {
+			table.AddRow(
+				l.Description,
+				strconv.FormatFloat(l.Quantity, 'f', -1, 64),
+				output.FormatAmount(l.UnitPrice),
+				output.FormatAmount(l.Net),
+				output.FormatAmount(l.Vat),
+				output.FormatAmount(l.Gross),
+				l.VatType,
+			)
+		}
+		table.Print()
+
+		fmt.Println()
+		totals := output.NewDetails()
+		totals.Add("Net", output.FormatAmount(cn.Net))
+		totals.Add("VAT", output.FormatAmount(cn.Vat))
+		totals.Add("Gross", output.FormatAmount(cn.Gross))
+		totals.Print()
+		return nil
+	},
+}
+
+var invoicesCreditCmd = &cobra.Command{
+	Use:   "credit <invoiceId>",
+	Short: "Credit an invoice fully or partially",
+	Long: `Create a credit note for an invoice.
+
+Without --lines or --amount the whole invoice is credited. With --lines,
+the given invoice lines (numbered from 1, as shown by 'fiken invoices get')
+are credited in full. With --amount, a single line for that amount
+including VAT is credited, using the VAT type of the first invoice line.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  fiken invoices credit 123
+  fiken invoices credit 123 --lines 2,3 --text "Returned goods"
+  fiken invoices credit 123 --amount 500 --send email`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		invoiceID, err := parseID(args[0], "invoice")
+		if err != nil {
+			return err
+		}
+		if len(invoiceCreditInput.lines) > 0 && invoiceCreditInput.amount != "" {
+			return fmt.Errorf("--lines and --amount cannot be combined")
+		}
+
+		issueDate := invoiceCreditInput.date
+		if issueDate == "" {
+			issueDate = time.Now().Format("2006-01-02")
+		}
+		if err := validateDate(issueDate); err != nil {
+			return err
+		}
+
+		var methods []string
+		if invoiceCreditInput.send != "" {
+			methods, err = parseSendMethods(invoiceCreditInput.send)
+			if err != nil {
+				return err
+			}
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var creditNoteID int64
+		if len(invoiceCreditInput.lines) == 0 && invoiceCreditInput.amount == "" {
+			req := api.FullCreditNoteRequest{
+				IssueDate:      issueDate,
+				InvoiceId:      invoiceID,
+				CreditNoteText: invoiceCreditInput.text,
+			}
+			creditNoteID, err = client.PostForID(fmt.Sprintf(api.EndpointCreditNotesFull, slug), req)
+		} else {
+			var invoice api.Invoice
+			_, err = client.Get(fmt.Sprintf(api.EndpointInvoice, slug, invoiceID), &invoice)
+			if err != nil {
+				return fmt.Errorf("fetching invoice: %w", err)
+			}
+
+			var req api.PartialCreditNoteRequest
+			req, err = buildPartialCreditNote(invoice, issueDate)
+			if err != nil {
+				return err
+			}
+			creditNoteID, err = client.PostForID(fmt.Sprintf(api.EndpointCreditNotesPartial, slug), req)
+		}
+		if err != nil {
+			return fmt.Errorf("creating credit note: %w", err)
+		}
+
+		if !jsonOutput {
+			output.PrintSuccess(fmt.Sprintf("Credit note created for invoice %d (ID %d)", invoiceID, creditNoteID))
+		}
+
+		if len(methods) > 0 {
+			req := api.SendCreditNoteRequest{
+				CreditNoteId:               creditNoteID,
+				Method:                     methods,
+				IncludeDocumentAttachments: invoiceSendInput.includeAttachments,
+				RecipientName:              invoiceSendInput.recipientName,
+				RecipientEmail:             invoiceSendInput.recipientEmail,
+				Message:                    invoiceSendInput.message,
+			}
+			if _, err := client.Post(fmt.Sprintf(api.EndpointCreditNotesSend, slug), req, nil); err != nil {
+				return fmt.Errorf("sending credit note %d: %w", creditNoteID, err)
+			}
+			if !jsonOutput {
+				output.PrintSuccess(fmt.Sprintf("Credit note %d sent", creditNoteID))
+			}
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"creditNoteId": creditNoteID})
+		}
+		return nil
+	},
+}
+
+// buildPartialCreditNote builds a partial credit note for the invoice from
+// the --lines or --amount flags.
+func buildPartialCreditNote(invoice api.Invoice, issueDate string) (api.PartialCreditNoteRequest, error) {
+	req := api.PartialCreditNoteRequest{
+		IssueDate:      issueDate,
+		InvoiceId:      invoice.InvoiceId,
+		CreditNoteText: invoiceCreditInput.text,
+		Currency:       invoice.Currency,
+	}
+
+	if invoiceCreditInput.amount != "" {
+		gross, err := parseAmount(invoiceCreditInput.amount)
+		if err != nil {
+			return req, err
+		}
+		if gross <= 0 || gross > invoice.Gross {
+			return req, fmt.Errorf("amount must be between 0 and the invoice total %s", output.FormatAmount(invoice.Gross))
+		}
+		if len(invoice.Lines) == 0 {
+			return req, fmt.Errorf("invoice %d has no lines", invoice.InvoiceId)
+		}
+
+		first := invoice.Lines[0]
+		// Unit prices are net, so take the VAT back out of the amount.
+		net := gross * 100 / (100 + first.VatInPercent)
+		req.Lines = []api.InvoiceLineRequest{{
+			Description:   fmt.Sprintf("Credit of invoice %d", invoice.InvoiceNumber),
+			Quantity:      1,
+			UnitPrice:     net,
+			VatType:       first.VatType,
+			IncomeAccount: first.IncomeAccount,
+		}}
+		return req, nil
+	}
+
+	for _, n := range invoiceCreditInput.lines {
+		if n < 1 || n > len(invoice.Lines) {
+			return req, fmt.Errorf("invoice %d has no line %d (it has %d lines)", invoice.InvoiceId, n, len(invoice.Lines))
+		}
+		l := invoice.Lines[n-1]
+		req.Lines = append(req.Lines, api.InvoiceLineRequest{
+			Description:   l.Description,
+			Comment:       l.Comment,
+			ProductId:     l.ProductId,
+			Quantity:      l.Quantity,
+			UnitPrice:     l.UnitPrice,
+			Discount:      l.Discount,
+			VatType:       l.VatType,
+			IncomeAccount: l.IncomeAccount,
+		})
+	}
+	return req, nil
+}
+
+func init() {
+	creditNotesListCmd.Flags().StringVar(&creditNotesFrom, "from", "", "Issue date from (YYYY-MM-DD)")
+	creditNotesListCmd.Flags().StringVar(&creditNotesTo, "to", "", "Issue date to (YYYY-MM-DD)")
+	creditNotesListCmd.Flags().Int64Var(&creditNotesCustomer, "customer", 0, "Filter by customer contact ID")
+	creditNotesListCmd.Flags().BoolVar(&creditNotesSettled, "settled", false, "Filter by settled status (--settled=false for unsettled)")
+	creditNotesListCmd.Flags().IntVar(&creditNotesLimit, "limit", 0, "Maximum number of credit notes to show (0 = all)")
+	creditNotesListCmd.Flags().IntVar(&creditNotesPageSize, "page-size", api.MaxPageSize, "Number of credit notes to fetch per request")
+
+	invoicesCreditCmd.Flags().IntSliceVar(&invoiceCreditInput.lines, "lines", nil, "Invoice line numbers to credit (e.g. 1,3)")
+	invoicesCreditCmd.Flags().StringVar(&invoiceCreditInput.amount, "amount", "", "Amount to credit including VAT, in kroner")
+	invoicesCreditCmd.Flags().StringVar(&invoiceCreditInput.date, "date", "", "Credit note date (YYYY-MM-DD, default today)")
+	invoicesCreditCmd.Flags().StringVar(&invoiceCreditInput.text, "text", "", "Credit note text")
+	invoicesCreditCmd.Flags().StringVar(&invoiceCreditInput.send, "send", "", "Send right away: email, ehf, efaktura, sms, letter or auto")
+	addSendFlags(invoicesCreditCmd)
+
+	creditNotesCmd.AddCommand(creditNotesListCmd)
+	creditNotesCmd.AddCommand(creditNotesGetCmd)
+	invoicesCmd.AddCommand(invoicesCreditCmd)
+	rootCmd.AddCommand(creditNotesCmd)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+func TestBuildPartialCreditNote(t *testing.T) {
+	invoice := api.Invoice{
+		InvoiceId:     123,
+		InvoiceNumber: 10042,
+		Currency:      "NOK",
+		Gross:         262500,
+		Lines: []api.InvoiceLine{
+			{Description: "Consulting", Comment: "March", ProductId: 5, Quantity: 2, UnitPrice: 100000, Discount: 10, VatType: "HIGH", VatInPercent: 25, IncomeAccount: "3000"},
+			{Description: "Travel", Quantity: 1, UnitPrice: 30000, VatType: "HIGH", VatInPercent: 25, IncomeAccount: "3100"},
+		},
+	}
+	consulting := api.InvoiceLineRequest{Description: "Consulting", Comment: "March", ProductId: 5, Quantity: 2, UnitPrice: 100000, Discount: 10, VatType: "HIGH", IncomeAccount: "3000"}
+	travel := api.InvoiceLineRequest{Description: "Travel", Quantity: 1, UnitPrice: 30000, VatType: "HIGH", IncomeAccount: "3100"}
+
+	tests := []struct {
+		name    string
+		lines   []int
+		amount  string
+		invoice api.Invoice
+		want    []api.InvoiceLineRequest
+		wantErr string
+	}{
+		{name: "one line", lines: []int{2}, invoice: invoice, want: []api.InvoiceLineRequest{travel}},
+		{name: "several lines", lines: []int{2, 1}, invoice: invoice, want: []api.InvoiceLineRequest{travel, consulting}},
+		{name: "line out of range", lines: []int{3}, invoice: invoice, wantErr: "has no line 3 (it has 2 lines)"},
+		{name: "line zero", lines: []int{0}, invoice: invoice, wantErr: "has no line 0"},
+		{
+			name:    "amount including VAT",
+			amount:  "500",
+			invoice: invoice,
+			want: []api.InvoiceLineRequest{
+				{Description: "Credit of invoice 10042", Quantity: 1, UnitPrice: 40000, VatType: "HIGH", IncomeAccount: "3000"},
+			},
+		},
+		{
+			name:    "whole invoice as amount",
+			amount:  "2 625,00",
+			invoice: invoice,
+			want: []api.InvoiceLineRequest{
+				{Description: "Credit of invoice 10042", Quantity: 1, UnitPrice: 210000, VatType: "HIGH", IncomeAccount: "3000"},
+			},
+		},
+		{name: "amount above total", amount: "2625,01", invoice: invoice, wantErr: "between 0 and the invoice total"},
+		{name: "zero amount", amount: "0", invoice: invoice, wantErr: "between 0 and the invoice total"},
+		{name: "negative amount", amount: "-10", invoice: invoice, wantErr: "between 0 and the invoice total"},
+		{name: "invalid amount", amount: "ten", invoice: invoice, wantErr: "invalid amount"},
+		{name: "amount without lines", amount: "10", invoice: api.Invoice{InvoiceId: 9, Gross: 5000}, wantErr: "invoice 9 has no lines"},
+	}
+
+	saved := invoiceCreditInput
+	t.Cleanup(func() { invoiceCreditInput = saved })
+
+	for _, tt := range tests {
+		invoiceCreditInput.lines = tt.lines
+		invoiceCreditInput.amount = tt.amount
+		invoiceCreditInput.text = "Returned goods"
+
+		req, err := buildPartialCreditNote(tt.invoice, "2024-04-01")
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error: %v", tt.name, err)
+			continue
+		}
+		if req.IssueDate != "2024-04-01" || req.InvoiceId != 123 || req.Currency != "NOK" || req.CreditNoteText != "Returned goods" {
+			t.Errorf("%s: request = %+v", tt.name, req)
+		}
+		if !reflect.DeepEqual(req.Lines, tt.want) {
+			t.Errorf("%s: lines =\n%+v\nwant\n%+v", tt.name, req.Lines, tt.want)
+		}
+	}
+}
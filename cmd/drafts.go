@@ -0,0 +1,560 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+// draftKind describes a document type that is created through drafts.
+// Fiken uses the same draft format for invoices, offers and order
+// confirmations; only the endpoints and the draft type differ.
+type draftKind struct {
+	name      string // document name, e.g. "invoice" or "order confirmation"
+	command   string // parent command, e.g. "invoices"
+	draftType string // Fiken draft type
+	drafts    string // drafts endpoint format (slug)
+	draft     string // single draft endpoint format (slug, draft ID)
+	finalize  string // endpoint format that turns a draft into a document (slug, draft ID)
+	idKey     string // JSON key for the ID of the finalized document
+	sendable  bool   // whether finalize can send the document (invoices only)
+}
+
+var invoiceDraftKind = draftKind{
+	name:      "invoice",
+	command:   "invoices",
+	draftType: "invoice",
+	drafts:    api.EndpointInvoiceDrafts,
+	draft:     api.EndpointInvoiceDraft,
+	finalize:  api.EndpointInvoiceDraftCreateInvoice,
+	idKey:     "invoiceId",
+	sendable:  true,
+}
+
+// newDraftsCmd builds the drafts subcommand for a document kind, with
+// list, get, create, update, delete and finalize.
+func newDraftsCmd(kind draftKind) *cobra.Command {
+	var (
+		limit    int
+		pageSize int
+		send     string
+	)
+
+	draftsCmd := &cobra.Command{
+		Use:   "drafts",
+		Short: fmt.Sprintf("Manage %s drafts", kind.name),
+		Long: fmt.Sprintf(`Stage, review and finalize %[1]s drafts.
+
+Drafts take the same flags and file format as 'fiken invoices create'.
+A draft becomes a real %[1]s with 'fiken %[2]s drafts finalize'.`, kind.name, kind.command),
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List %s drafts", kind.name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			endpoint := fmt.Sprintf(kind.drafts, slug)
+
+			drafts, err := fetchPages[api.Draft](client, endpoint, nil, pageSize, limit)
+			if err != nil {
+				return fmt.Errorf("fetching %s drafts: %w", kind.name, err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(drafts)
+			}
+
+			if len(drafts) == 0 {
+				output.PrintInfo(fmt.Sprintf("No %s drafts found.", kind.name))
+				return nil
+			}
+
+			printDraftTable(drafts)
+
+			fmt.Printf("\n%d drafts\n", len(drafts))
+			return nil
+		},
+	}
+
+	getCmd := &cobra.Command{
+		Use:   "get <draftId>",
+		Short: fmt.Sprintf("Show %s draft", withArticle(kind.name)),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID, err := parseID(args[0], "draft")
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			var draft api.Draft
+			_, err = client.Get(fmt.Sprintf(kind.draft, slug, draftID), &draft)
+			if err != nil {
+				return fmt.Errorf("fetching %s draft: %w", kind.name, err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(draft)
+			}
+
+			printDraft(draft)
+			return nil
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: fmt.Sprintf("Create %s draft", withArticle(kind.name)),
+		Example: fmt.Sprintf(`  fiken %[1]s drafts create --customer "Acme AS" --line "desc=Support,qty=5,price=950"
+  fiken %[1]s drafts create --file %[2]s.yaml`, kind.command, kind.draftType),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			spec, err := readInvoiceSpec(cmd)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			draftID, err := createDraft(client, slug, kind, spec)
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(map[string]int64{"draftId": draftID})
+			}
+			output.PrintSuccess(fmt.Sprintf("%s draft created (ID %d)", capitalize(kind.name), draftID))
+			return nil
+		},
+	}
+
+	updateCmd := &cobra.Command{
+		Use:   "update <draftId>",
+		Short: fmt.Sprintf("Update %s draft", withArticle(kind.name)),
+		Long: fmt.Sprintf(`Update %s draft. Only the fields given as flags or in the file
+are changed. Lines given with --line or in the file replace all existing lines.`, withArticle(kind.name)),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID, err := parseID(args[0], "draft")
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			endpoint := fmt.Sprintf(kind.draft, slug, draftID)
+
+			var draft api.Draft
+			_, err = client.Get(endpoint, &draft)
+			if err != nil {
+				return fmt.Errorf("fetching %s draft: %w", kind.name, err)
+			}
+
+			spec := draftToSpec(draft)
+			if invoiceCreateInput.file != "" {
+				var fromFile invoiceSpec
+				if err := readInputFile(invoiceCreateInput.file, &fromFile); err != nil {
+					return err
+				}
+				mergeInvoiceSpec(&spec, fromFile)
+			}
+			if len(invoiceCreateInput.lines) > 0 || len(invoiceCreateInput.products) > 0 {
+				spec.Lines = nil
+			}
+			if err := applyInvoiceCreateFlags(cmd, &spec); err != nil {
+				return err
+			}
+
+			req, err := buildDraftRequest(client, slug, spec, draft.Type)
+			if err != nil {
+				return err
+			}
+
+			if err := client.Put(endpoint, req, nil); err != nil {
+				return fmt.Errorf("updating %s draft: %w", kind.name, err)
+			}
+
+			output.PrintSuccess(fmt.Sprintf("%s draft %d updated", capitalize(kind.name), draftID))
+			return nil
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete <draftId>",
+		Short: fmt.Sprintf("Delete %s draft", withArticle(kind.name)),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID, err := parseID(args[0], "draft")
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			if !confirm(fmt.Sprintf("Delete %s draft %d?", kind.name, draftID)) {
+				output.PrintInfo("Aborted.")
+				return nil
+			}
+
+			if err := client.Delete(fmt.Sprintf(kind.draft, slug, draftID)); err != nil {
+				return fmt.Errorf("deleting %s draft: %w", kind.name, err)
+			}
+
+			output.PrintSuccess(fmt.Sprintf("%s draft %d deleted", capitalize(kind.name), draftID))
+			return nil
+		},
+	}
+
+	finalizeCmd := &cobra.Command{
+		Use:     "finalize <draftId>",
+		Short:   fmt.Sprintf("Turn %s draft into %s", withArticle(kind.name), withArticle(kind.name)),
+		Args:    cobra.ExactArgs(1),
+		Example: fmt.Sprintf(`  fiken %s drafts finalize 42`, kind.command),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			draftID, err := parseID(args[0], "draft")
+			if err != nil {
+				return err
+			}
+
+			var methods []string
+			if send != "" {
+				methods, err = parseSendMethods(send)
+				if err != nil {
+					return err
+				}
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			docID, err := finalizeDraft(client, slug, kind, draftID)
+			if err != nil {
+				return err
+			}
+
+			if !jsonOutput {
+				output.PrintSuccess(fmt.Sprintf("%s created from draft %d (ID %d)", capitalize(kind.name), draftID, docID))
+			}
+
+			if len(methods) > 0 {
+				if err := sendInvoice(client, slug, docID, methods); err != nil {
+					return err
+				}
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(map[string]int64{kind.idKey: docID})
+			}
+			return nil
+		},
+	}
+
+	listCmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of drafts to show (0 = all)")
+	listCmd.Flags().IntVar(&pageSize, "page-size", api.MaxPageSize, "Number of drafts to fetch per request")
+
+	addInvoiceSpecFlags(createCmd)
+	addInvoiceSpecFlags(updateCmd)
+
+	if kind.sendable {
+		finalizeCmd.Example += fmt.Sprintf("\n  fiken %s drafts finalize 42 --send ehf,email", kind.command)
+		finalizeCmd.Flags().StringVar(&send, "send", "", "Send the invoice: email, ehf, efaktura, sms, letter or auto")
+		addSendFlags(finalizeCmd)
+	}
+
+	draftsCmd.AddCommand(listCmd)
+	draftsCmd.AddCommand(getCmd)
+	draftsCmd.AddCommand(createCmd)
+	draftsCmd.AddCommand(updateCmd)
+	draftsCmd.AddCommand(deleteCmd)
+	draftsCmd.AddCommand(finalizeCmd)
+	return draftsCmd
+}
+
+// readInvoiceSpec reads the invoice file given with --file, if any, and
+// applies the invoice flags set on cmd.
+func readInvoiceSpec(cmd *cobra.Command) (invoiceSpec, error) {
+	spec := invoiceSpec{}
+	if invoiceCreateInput.file != "" {
+		if err := readInputFile(invoiceCreateInput.file, &spec); err != nil {
+			return spec, err
+		}
+	}
+	if err := applyInvoiceCreateFlags(cmd, &spec); err != nil {
+		return spec, err
+	}
+	return spec, nil
+}
+
+// createDraft builds a draft of the given kind from spec and creates it.
+func createDraft(client *api.Client, slug string, kind draftKind, spec invoiceSpec) (int64, error) {
+	req, err := buildDraftRequest(client, slug, spec, kind.draftType)
+	if err != nil {
+		return 0, err
+	}
+
+	draftID, err := client.PostForID(fmt.Sprintf(kind.drafts, slug), req)
+	if err != nil {
+		return 0, fmt.Errorf("creating %s draft: %w", kind.name, err)
+	}
+	return draftID, nil
+}
+
+// finalizeDraft turns a draft into a document and returns the document ID.
+func finalizeDraft(client *api.Client, slug string, kind draftKind, draftID int64) (int64, error) {
+	docID, err := client.PostForID(fmt.Sprintf(kind.finalize, slug, draftID), nil)
+	if err != nil {
+		return 0, fmt.Errorf("creating %s from draft %d: %w", kind.name, draftID, err)
+	}
+	return docID, nil
+}
+
+// createFromDraft creates a draft from spec and finalizes it right away,
+// returning the ID of the finalized document.
+func createFromDraft(client *api.Client, slug string, kind draftKind, spec invoiceSpec) (int64, error) {
+	draftID, err := createDraft(client, slug, kind, spec)
+	if err != nil {
+		return 0, err
+	}
+	return finalizeDraft(client, slug, kind, draftID)
+}
+
+// withArticle prefixes name with "a" or "an".
+func withArticle(name string) string {
+	if strings.ContainsRune("aeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// buildDraftRequest resolves spec like an invoice and converts it into a
+// draft request of the given type.
+func buildDraftRequest(client *api.Client, slug string, spec invoiceSpec, draftType string) (api.DraftRequest, error) {
+	inv, err := buildInvoiceRequest(client, slug, spec)
+	if err != nil {
+		return api.DraftRequest{}, err
+	}
+
+	issued, _ := time.Parse("2006-01-02", inv.IssueDate)
+	due, _ := time.Parse("2006-01-02", inv.DueDate)
+
+	req := api.DraftRequest{
+		Type:             draftType,
+		IssueDate:        inv.IssueDate,
+		DaysUntilDueDate: int(due.Sub(issued).Hours() / 24),
+		InvoiceText:      inv.InvoiceText,
+		Currency:         inv.Currency,
+		YourReference:    inv.YourReference,
+		OurReference:     inv.OurReference,
+		OrderReference:   inv.OrderReference,
+		CustomerId:       inv.CustomerId,
+		ContactPersonId:  inv.ContactPersonId,
+		BankAccountCode:  inv.BankAccountCode,
+		PaymentAccount:   spec.PaymentAccount,
+		ProjectId:        inv.ProjectId,
+	}
+	for _, l := range inv.Lines {
+		req.Lines = append(req.Lines, api.DraftLine{
+			Description:   l.Description,
+			Comment:       l.Comment,
+			ProductId:     l.ProductId,
+			Quantity:      l.Quantity,
+			UnitPrice:     l.UnitPrice,
+			Discount:      l.Discount,
+			VatType:       l.VatType,
+			IncomeAccount: l.IncomeAccount,
+		})
+	}
+	return req, nil
+}
+
+// mergeInvoiceSpec copies the fields set in from into spec. Lines in from
+// replace all lines of spec rather than being merged into them.
+func mergeInvoiceSpec(spec *invoiceSpec, from invoiceSpec) {
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{(*string)(&spec.Customer), string(from.Customer)},
+		{&spec.IssueDate, from.IssueDate},
+		{&spec.DueDate, from.DueDate},
+		{&spec.BankAccount, from.BankAccount},
+		{&spec.PaymentAccount, from.PaymentAccount},
+		{&spec.Currency, from.Currency},
+		{&spec.InvoiceText, from.InvoiceText},
+		{&spec.OurReference, from.OurReference},
+		{&spec.YourReference, from.YourReference},
+		{&spec.OrderReference, from.OrderReference},
+		{(*string)(&spec.Project), string(from.Project)},
+		{&spec.Send, from.Send},
+	} {
+		if field.src != "" {
+			*field.dst = field.src
+		}
+	}
+	if from.ContactPersonId != 0 {
+		spec.ContactPersonId = from.ContactPersonId
+	}
+	if from.DueDays != 0 {
+		spec.DueDays = from.DueDays
+	}
+	if len(from.Lines) > 0 {
+		spec.Lines = from.Lines
+	}
+}
+
+// draftToSpec converts a fetched draft into an invoiceSpec for updating.
+// Every field the draft request takes is copied, so that an update only
+// changes what the user gives.
+func draftToSpec(d api.Draft) invoiceSpec {
+	spec := invoiceSpec{
+		ContactPersonId: d.ContactPersonId,
+		IssueDate:       d.IssueDate,
+		DueDays:         d.DaysUntilDueDate,
+		BankAccount:     d.BankAccountNumber,
+		PaymentAccount:  d.PaymentAccount,
+		Currency:        d.Currency,
+		InvoiceText:     d.InvoiceText,
+		OurReference:    d.OurReference,
+		YourReference:   d.YourReference,
+		OrderReference:  d.OrderReference,
+	}
+	if len(d.Customers) > 0 {
+		spec.Customer = idOrName(strconv.FormatInt(d.Customers[0].ContactId, 10))
+	}
+	if d.ProjectId != 0 {
+		spec.Project = idOrName(strconv.FormatInt(d.ProjectId, 10))
+	}
+	for _, l := range d.Lines {
+		spec.Lines = append(spec.Lines, invoiceLineSpec{
+			Product:       l.ProductId,
+			Description:   l.Description,
+			Comment:       l.Comment,
+			Quantity:      l.Quantity,
+			UnitPrice:     amount(l.UnitPrice),
+			Discount:      l.Discount,
+			VatType:       l.VatType,
+			IncomeAccount: l.IncomeAccount,
+		})
+	}
+	return spec
+}
+
+func printDraftTable(drafts []api.Draft) {
+	table := output.NewTable("ID", "TYPE", "ISSUE DATE", "CUSTOMER", "NET", "GROSS")
+	for _, d := range drafts {
+		customer := ""
+		if len(d.Customers) > 0 {
+			customer = d.Customers[0].Name
+		}
+		table.AddRow(
+			fmt.Sprintf("%d", d.DraftId),
+			d.Type,
+			d.IssueDate,
+			customer,
+			output.FormatAmount(d.Net),
+			output.FormatAmount(d.Gross),
+		)
+	}
+	table.Print()
+}
+
+func printDraft(d api.Draft) {
+	details := output.NewDetails()
+	details.Add("ID", fmt.Sprintf("%d", d.DraftId))
+	details.Add("Type", d.Type)
+	for _, c := range d.Customers {
+		details.Add("Customer", fmt.Sprintf("%s (%d)", c.Name, c.ContactId))
+	}
+	details.Add("Issue date", d.IssueDate)
+	details.Add("Days until due", fmt.Sprintf("%d", d.DaysUntilDueDate))
+	details.Add("Our reference", d.OurReference)
+	details.Add("Your reference", d.YourReference)
+	details.Add("Order reference", d.OrderReference)
+	details.Add("Bank account", d.BankAccountNumber)
+	details.Add("Currency", d.Currency)
+	details.Add("Text", d.InvoiceText)
+	details.Print()
+
+	fmt.Println()
+	table := output.NewTable("DESCRIPTION", "QTY", "UNIT PRICE", "NET", "GROSS", "VAT TYPE")
+	for _, l := range d.Lines {
+		table.AddRow(
+			l.Description,
+			strconv.FormatFloat(l.Quantity, 'f', -1, 64),
+			output.FormatAmount(l.UnitPrice),
+			output.FormatAmount(l.Net),
+			output.FormatAmount(l.Gross),
+			l.VatType,
+		)
+	}
+	table.Print()
+
+	fmt.Println()
+	totals := output.NewDetails()
+	totals.Add("Net", output.FormatAmount(d.Net))
+	totals.Add("Gross", output.FormatAmount(d.Gross))
+	totals.Print()
+}
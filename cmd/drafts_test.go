@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+// TestDraftToSpecRoundTrip checks that updating a draft without changing
+// anything sends back what the draft already has.
+func TestDraftToSpecRoundTrip(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/companies/acme/contacts/7":
+			writeJSON(t, w, api.Contact{ContactId: 7, Name: "Acme AS", DaysUntilInvoicingDueDate: 30})
+		case "/companies/acme/bankAccounts":
+			writeJSON(t, w, []api.BankAccount{
+				{BankAccountId: 1, Name: "Drift", AccountCode: "1920:10001", BankAccountNumber: "1234.56.78903"},
+				{BankAccountId: 2, Name: "Skatt", AccountCode: "1920:10002", BankAccountNumber: "1234.56.78911"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	draft := api.Draft{
+		DraftId:           42,
+		Type:              "cash_invoice",
+		IssueDate:         "2024-03-01",
+		DaysUntilDueDate:  10,
+		InvoiceText:       "Thanks",
+		Currency:          "EUR",
+		YourReference:     "Kari",
+		OurReference:      "Ola",
+		OrderReference:    "PO-1",
+		BankAccountNumber: "1234.56.78911",
+		PaymentAccount:    "1900",
+		Customers:         []api.Contact{{ContactId: 7, Name: "Acme AS"}},
+		ContactPersonId:   11,
+		Lines: []api.DraftLine{
+			{Description: "Consulting", Comment: "March", Quantity: 2, UnitPrice: 100000, Discount: 10, VatType: "HIGH", IncomeAccount: "3000"},
+		},
+	}
+	got, err := buildDraftRequest(client, "acme", draftToSpec(draft), draft.Type)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := api.DraftRequest{
+		Type:             "cash_invoice",
+		IssueDate:        "2024-03-01",
+		DaysUntilDueDate: 10,
+		InvoiceText:      "Thanks",
+		Currency:         "EUR",
+		YourReference:    "Kari",
+		OurReference:     "Ola",
+		OrderReference:   "PO-1",
+		CustomerId:       7,
+		ContactPersonId:  11,
+		BankAccountCode:  "1920:10002",
+		PaymentAccount:   "1900",
+		Lines: []api.DraftLine{
+			{Description: "Consulting", Comment: "March", Quantity: 2, UnitPrice: 100000, Discount: 10, VatType: "HIGH", IncomeAccount: "3000"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildDraftRequest(draftToSpec(draft)) =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+// TestMergeInvoiceSpec checks that a drafts update file replaces the lines
+// of the draft instead of filling in what the new lines leave out.
+func TestMergeInvoiceSpec(t *testing.T) {
+	spec := invoiceSpec{
+		Customer:    "7",
+		IssueDate:   "2024-03-01",
+		DueDays:     10,
+		InvoiceText: "Thanks",
+		Lines: []invoiceLineSpec{
+			{Product: 5, Description: "Consulting", Comment: "March", Quantity: 2, UnitPrice: 100000, Discount: 10, IncomeAccount: "3100"},
+			{Description: "Travel", UnitPrice: 5000},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "update.json")
+	file := `{"invoiceText": "Thank you", "lines": [{"description": "Support", "unitPrice": 800}]}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var fromFile invoiceSpec
+	if err := readInputFile(path, &fromFile); err != nil {
+		t.Fatal(err)
+	}
+	mergeInvoiceSpec(&spec, fromFile)
+
+	want := invoiceSpec{
+		Customer:    "7",
+		IssueDate:   "2024-03-01",
+		DueDays:     10,
+		InvoiceText: "Thank you",
+		Lines:       []invoiceLineSpec{{Description: "Support", UnitPrice: 80000}},
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("merged spec =\n%+v\nwant\n%+v", spec, want)
+	}
+
+	// A file without lines keeps the draft's lines.
+	mergeInvoiceSpec(&spec, invoiceSpec{OurReference: "Ola"})
+	if len(spec.Lines) != 1 || spec.OurReference != "Ola" || spec.InvoiceText != "Thank you" {
+		t.Errorf("merging a file without lines gave %+v", spec)
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+)
+
+// fetchPages fetches a paginated list endpoint into a slice using
+// api.List. It stops when all pages are read or when limit results have
+// been collected (limit <= 0 means no limit).
+func fetchPages[T any](client *api.Client, endpoint string, params url.Values, pageSize, limit int) ([]T, error) {
+	return api.NewList[T](client, endpoint, params).PageSize(pageSize).Limit(limit).Collect()
+}
+
+// clampPageSize returns the page size api.List uses for n: n limited to
+// api.MaxPageSize, or api.DefaultPageSize if n <= 0.
+func clampPageSize(n int) int {
+	switch {
+	case n <= 0:
+		return api.DefaultPageSize
+	case n > api.MaxPageSize:
+		return api.MaxPageSize
+	}
+	return n
+}
+
+// filterItems returns the items for which keep returns true, truncated to
+// limit items (limit <= 0 means no limit). It is used for filters that the
+// API does not support and that are therefore applied locally.
+func filterItems[T any](items []T, keep func(T) bool, limit int) []T {
+	filtered := items[:0]
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	if limit > 0 && len(filtered) > limit {
+		filtered = filtered[:limit]
+	}
+	return filtered
+}
+
+// parseID parses a numeric resource ID from a command argument.
+func parseID(arg, what string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimSpace(arg), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid %s ID: %q", what, arg)
+	}
+	return id, nil
+}
+
+// validateDate checks that s is a date in YYYY-MM-DD format.
+func validateDate(s string) error {
+	if _, err := time.Parse("2006-01-02", s); err != nil {
+		return fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", s)
+	}
+	return nil
+}
+
+// yesNo formats a boolean for table output.
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+// confirm asks the user to confirm a destructive action.
+// In non-interactive mode (--no-input) the action is always confirmed.
+func confirm(prompt string) bool {
+	if noInput {
+		return true
+	}
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	reader := bufio.NewReader(os.Stdin)
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// downloadTo downloads a file from rawURL and saves it at path.
+// A path of "-" writes the file to stdout.
+func downloadTo(client *api.Client, rawURL, path string) error {
+	if path == "-" {
+		return client.Download(rawURL, os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	if err := client.Download(rawURL, f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+	return f.Close()
+}
+
+// uploadFile uploads the file at path to a multipart endpoint such as a
+// document's attachments. The filename field is set from path.
+func uploadFile(client *api.Client, endpoint, path string, fields map[string]string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close()
+
+	filename := filepath.Base(path)
+	form := map[string]string{"filename": filename}
+	for k, v := range fields {
+		form[k] = v
+	}
+	return client.PostMultipart(endpoint, form, filename, f)
+}
+
+// printAttachments prints a table of document attachments.
+func printAttachments(attachments []api.Attachment) {
+	table := output.NewTable("IDENTIFIER", "TYPE", "COMMENT")
+	for _, a := range attachments {
+		table.AddRow(a.Identifier, a.Type, a.Comment)
+	}
+	table.Print()
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+// newTestClient returns a client that sends its requests to handler and
+// does not retry.
+func newTestClient(t *testing.T, handler http.Handler) *api.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return api.NewClient("test-token").WithBaseURL(srv.URL).WithRetryPolicy(api.RetryPolicy{})
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+func TestClampPageSize(t *testing.T) {
+	tests := []struct{ in, want int }{
+		{0, api.DefaultPageSize},
+		{-5, api.DefaultPageSize},
+		{1, 1},
+		{25, 25},
+		{api.MaxPageSize, api.MaxPageSize},
+		{500, api.MaxPageSize},
+	}
+	for _, tt := range tests {
+		if got := clampPageSize(tt.in); got != tt.want {
+			t.Errorf("clampPageSize(%d) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -3,19 +3,96 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 
 	"github.com/jakoblind/fiken-cli/api"
 	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
-var inboxStatus string
+var (
+	inboxStatus      string
+	inboxName        string
+	inboxDescription string
+	inboxOut         string
+)
 
 var inboxCmd = &cobra.Command{
 	Use:   "inbox",
-	Short: "List EHF inbox documents",
-	Long:  "List documents in the EHF (electronic invoice) inbox.",
+	Short: "Manage inbox documents",
+	Long: `List, upload and download documents in the inbox, where EHF invoices
+and uploaded receipts wait to be booked.
+
+Without a subcommand the inbox is listed.`,
+	Args: cobra.NoArgs,
+	RunE: runInboxList,
+}
+
+var inboxListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List inbox documents",
+	Args:  cobra.NoArgs,
+	RunE:  runInboxList,
+}
+
+func runInboxList(cmd *cobra.Command, args []string) error {
+	client, err := getClient()
+	if err != nil {
+		return err
+	}
+
+	slug, err := resolveCompany(client)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	if inboxStatus != "" {
+		params.Set("status", inboxStatus)
+	}
+
+	endpoint := fmt.Sprintf(api.EndpointInbox, slug)
+	documents, err := fetchPages[api.InboxDocument](client, endpoint, params, api.DefaultPageSize, 0)
+	if err != nil {
+		return fmt.Errorf("fetching inbox: %w", err)
+	}
+
+	if jsonOutput {
+		return output.PrintJSON(documents)
+	}
+
+	if len(documents) == 0 {
+		output.PrintInfo("Inbox is empty.")
+		return nil
+	}
+
+	table := output.NewTable("ID", "NAME", "FILENAME", "STATUS", "DATE")
+	for _, d := range documents {
+		table.AddRow(
+			fmt.Sprintf("%d", d.DocumentId),
+			d.Name,
+			d.Filename,
+			d.Status,
+			d.CreatedDate.Format("2006-01-02"),
+		)
+	}
+	table.Print()
+
+	fmt.Printf("\n%d documents\n", len(documents))
+	return nil
+}
+
+var inboxGetCmd = &cobra.Command{
+	Use:   "get <documentId>",
+	Short: "Show an inbox document",
+	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		documentID, err := parseID(args[0], "document")
+		if err != nil {
+			return err
+		}
+
 		client, err := getClient()
 		if err != nil {
 			return err
@@ -26,58 +103,233 @@ var inboxCmd = &cobra.Command{
 			return err
 		}
 
-		params := url.Values{}
-		if inboxStatus != "" {
-			params.Set("status", inboxStatus)
+		doc, err := fetchInboxDocument(client, slug, documentID)
+		if err != nil {
+			return err
 		}
-		params.Set("pageSize", "25")
 
-		endpoint := fmt.Sprintf(api.EndpointInbox, slug)
+		if jsonOutput {
+			return output.PrintJSON(doc)
+		}
 
-		var documents []api.InboxDocument
-		page := 0
-		for {
-			params.Set("page", fmt.Sprintf("%d", page))
-			var pageDocs []api.InboxDocument
-			pagination, err := client.GetWithParams(endpoint, params, &pageDocs)
-			if err != nil {
-				return fmt.Errorf("fetching inbox: %w", err)
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", doc.DocumentId))
+		d.Add("Name", doc.Name)
+		d.Add("Description", doc.Description)
+		d.Add("Filename", doc.Filename)
+		d.Add("Status", doc.Status)
+		d.Add("Created", doc.CreatedDate.Format("2006-01-02 15:04"))
+		d.Print()
+		return nil
+	},
+}
+
+var inboxUploadCmd = &cobra.Command{
+	Use:   "upload <file>...",
+	Short: "Upload documents to the inbox",
+	Long: `Upload receipts and other documents (PDF or images) to the inbox.
+The name defaults to the file name.`,
+	Example: `  fiken inbox upload receipt.pdf
+  fiken inbox upload ~/Downloads/*.jpg
+  fiken inbox upload taxi.pdf --name "Taxi Oslo" --description "Client meeting"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if inboxName != "" && len(args) > 1 {
+			return fmt.Errorf("--name can only be used when uploading a single file")
+		}
+		for _, path := range args {
+			if _, err := os.Stat(path); err != nil {
+				return err
 			}
-			documents = append(documents, pageDocs...)
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
 
-			if pagination == nil || page+1 >= pagination.PageCount {
-				break
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointInbox, slug)
+		for _, path := range args {
+			name := inboxName
+			if name == "" {
+				name = filepath.Base(path)
+			}
+			fields := map[string]string{"name": name}
+			if inboxDescription != "" {
+				fields["description"] = inboxDescription
+			}
+			if err := uploadFile(client, endpoint, path, fields); err != nil {
+				return fmt.Errorf("uploading %s: %w", path, err)
+			}
+			if !jsonOutput {
+				output.PrintSuccess(fmt.Sprintf("Uploaded %s", filepath.Base(path)))
 			}
-			page++
 		}
 
 		if jsonOutput {
-			return output.PrintJSON(documents)
+			return output.PrintJSON(map[string]int{"uploaded": len(args)})
+		}
+		return nil
+	},
+}
+
+var inboxDownloadCmd = &cobra.Command{
+	Use:   "download <documentId>",
+	Short: "Download the file of an inbox document",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		documentID, err := parseID(args[0], "document")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		doc, err := fetchInboxDocument(client, slug, documentID)
+		if err != nil {
+			return err
+		}
+		if doc.DownloadUrl == "" {
+			return fmt.Errorf("inbox document %d has no file available", documentID)
 		}
 
-		if len(documents) == 0 {
-			output.PrintInfo("Inbox is empty.")
-			return nil
+		path := inboxOut
+		if path == "" {
+			path = filepath.Base(doc.Filename)
+		}
+		if path == "" || path == "." {
+			path = fmt.Sprintf("inbox-%d", documentID)
 		}
 
-		table := output.NewTable("ID", "NAME", "FILENAME", "STATUS", "DATE")
-		for _, d := range documents {
-			table.AddRow(
-				fmt.Sprintf("%d", d.DocumentId),
-				d.Name,
-				d.Filename,
-				d.Status,
-				d.CreatedDate.Format("2006-01-02"),
-			)
+		if err := downloadTo(client, doc.DownloadUrl, path); err != nil {
+			return fmt.Errorf("downloading inbox document: %w", err)
 		}
-		table.Print()
 
-		fmt.Printf("\n%d documents\n", len(documents))
+		if path != "-" {
+			output.PrintSuccess(fmt.Sprintf("Saved inbox document %d to %s", documentID, path))
+		}
 		return nil
 	},
 }
 
+var inboxToPurchaseCmd = &cobra.Command{
+	Use:   "to-purchase <documentId>",
+	Short: "Create a purchase from an inbox document",
+	Long: `Create a purchase with the file of an inbox document attached.
+
+Takes the same flags and file format as 'fiken purchases create'; the
+inbox document takes the place of --attach.`,
+	Example: `  fiken inbox to-purchase 123 --kind cash_purchase --line "desc=Taxi,account=7140,gross=389,vat=LOW"
+  fiken inbox to-purchase 123 --kind supplier --supplier "Acme AS" --due-date 2024-02-14 \
+    --line "desc=Hosting,account=6810,net=800"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("attach") {
+			return fmt.Errorf("--attach cannot be used with to-purchase; the inbox document is attached")
+		}
+
+		documentID, err := parseID(args[0], "document")
+		if err != nil {
+			return err
+		}
+
+		spec := purchaseSpec{}
+		if purchaseCreateInput.file != "" {
+			if err := readInputFile(purchaseCreateInput.file, &spec); err != nil {
+				return err
+			}
+		}
+		if err := applyPurchaseCreateFlags(cmd, &spec); err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		doc, err := fetchInboxDocument(client, slug, documentID)
+		if err != nil {
+			return err
+		}
+		if doc.DownloadUrl == "" {
+			return fmt.Errorf("inbox document %d has no file available", documentID)
+		}
+
+		// Download into a temporary directory so the attachment keeps the
+		// document's file name.
+		dir, err := os.MkdirTemp("", "fiken-inbox-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(dir)
+
+		filename := filepath.Base(doc.Filename)
+		if filename == "" || filename == "." {
+			filename = fmt.Sprintf("inbox-%d", documentID)
+		}
+		spec.Attachment = filepath.Join(dir, filename)
+		if err := downloadTo(client, doc.DownloadUrl, spec.Attachment); err != nil {
+			return fmt.Errorf("downloading inbox document: %w", err)
+		}
+
+		purchaseID, err := createPurchase(client, slug, spec)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"purchaseId": purchaseID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Purchase created from inbox document %d (ID %d)", documentID, purchaseID))
+		return nil
+	},
+}
+
+// fetchInboxDocument fetches a single inbox document by ID.
+func fetchInboxDocument(client *api.Client, slug string, documentID int64) (api.InboxDocument, error) {
+	var doc api.InboxDocument
+	_, err := client.Get(fmt.Sprintf(api.EndpointInboxDocument, slug, documentID), &doc)
+	if err != nil {
+		return api.InboxDocument{}, fmt.Errorf("fetching inbox document: %w", err)
+	}
+	return doc, nil
+}
+
 func init() {
 	inboxCmd.Flags().StringVar(&inboxStatus, "status", "", "Filter by status (pending, processed)")
+	inboxListCmd.Flags().StringVar(&inboxStatus, "status", "", "Filter by status (pending, processed)")
+
+	inboxUploadCmd.Flags().StringVar(&inboxName, "name", "", "Document name (default the file name)")
+	inboxUploadCmd.Flags().StringVar(&inboxDescription, "description", "", "Document description")
+
+	inboxDownloadCmd.Flags().StringVarP(&inboxOut, "output", "o", "", "Output file (default the document's file name, - for stdout)")
+
+	addPurchaseCreateFlags(inboxToPurchaseCmd)
+	inboxToPurchaseCmd.Flags().MarkHidden("attach")
+
+	inboxCmd.AddCommand(inboxListCmd)
+	inboxCmd.AddCommand(inboxGetCmd)
+	inboxCmd.AddCommand(inboxUploadCmd)
+	inboxCmd.AddCommand(inboxDownloadCmd)
+	inboxCmd.AddCommand(inboxToPurchaseCmd)
 	rootCmd.AddCommand(inboxCmd)
 }
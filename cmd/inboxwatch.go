@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inboxWatchState  string
+	inboxWatchOnce   bool
+	inboxWatchSettle time.Duration
+)
+
+// inboxWatchExtensions are the file types inbox watch uploads.
+var inboxWatchExtensions = []string{".pdf", ".jpg", ".jpeg", ".png"}
+
+// inboxWatchStateFile is the default state file name inside the watched
+// directory.
+const inboxWatchStateFile = ".fiken-inbox-state.json"
+
+// watchState records the files inbox watch has uploaded, keyed by the
+// SHA-256 of their content, so that a file is never uploaded twice even if
+// the watcher is restarted before it was moved to done/.
+type watchState struct {
+	path     string
+	Uploaded map[string]watchUpload `json:"uploaded"`
+}
+
+type watchUpload struct {
+	Name       string    `json:"name"`
+	UploadedAt time.Time `json:"uploadedAt"`
+}
+
+var inboxWatchCmd = &cobra.Command{
+	Use:   "watch <dir>",
+	Short: "Upload new files in a folder to the inbox",
+	Long: `Watch a folder and upload new PDF, JPG and PNG files to the inbox.
+
+Files already in the folder are uploaded first. Uploaded files are moved
+to the done/ subfolder, and files that fail to upload to failed/. Other
+files are left alone. A file is uploaded once it has not changed for
+--settle, so scanners can finish writing it.
+
+The content hashes of uploaded files are kept in a state file
+(default <dir>/` + inboxWatchStateFile + `), so a restart never uploads
+the same file twice. If the state file cannot be written, the file just
+uploaded is left in place and the watcher stops. Stop with Ctrl-C.`,
+	Example: `  fiken inbox watch ~/Scans
+  fiken inbox watch /srv/scanner --state /var/lib/fiken/inbox-state.json
+  fiken inbox watch ~/Scans --once         # Upload what is there and exit`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("%s is not a directory", dir)
+		}
+		for _, sub := range []string{"done", "failed"} {
+			if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+				return fmt.Errorf("creating %s folder: %w", sub, err)
+			}
+		}
+
+		statePath := inboxWatchState
+		if statePath == "" {
+			statePath = filepath.Join(dir, inboxWatchStateFile)
+		}
+		state, err := loadWatchState(statePath)
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointInbox, slug)
+
+		// Each event restarts the file's settle timer; the file is
+		// processed once the timer fires.
+		ctx := cmd.Context()
+		pending := map[string]*time.Timer{}
+		ready := make(chan string)
+		schedule := func(path string) {
+			if t, ok := pending[path]; ok {
+				t.Reset(inboxWatchSettle)
+				return
+			}
+			pending[path] = time.AfterFunc(inboxWatchSettle, func() {
+				select {
+				case ready <- path:
+				case <-ctx.Done():
+				}
+			})
+		}
+
+		// Start watching before the initial scan so that files dropped in
+		// meanwhile are not missed, and so that files still being written
+		// when the scan finds them settle like new ones.
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("starting watcher: %w", err)
+		}
+		defer watcher.Close()
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("watching %s: %w", dir, err)
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.Type().IsRegular() {
+				schedule(filepath.Join(dir, e.Name()))
+			}
+		}
+		if inboxWatchOnce {
+			if len(pending) == 0 {
+				return nil
+			}
+		} else {
+			output.PrintInfo(fmt.Sprintf("Watching %s for new files (Ctrl-C to stop)", dir))
+		}
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return nil
+				}
+				if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+					continue
+				}
+				// With --once only the files found by the scan are handled.
+				if _, ok := pending[event.Name]; inboxWatchOnce && !ok {
+					continue
+				}
+				schedule(event.Name)
+			case path := <-ready:
+				if _, ok := pending[path]; !ok {
+					continue
+				}
+				delete(pending, path)
+				if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+					if err := processWatchedFile(client, endpoint, dir, path, state); err != nil {
+						for _, t := range pending {
+							t.Stop()
+						}
+						return err
+					}
+				}
+				if inboxWatchOnce && len(pending) == 0 {
+					return nil
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return nil
+				}
+				output.PrintError(fmt.Sprintf("watch: %v", err))
+			case <-ctx.Done():
+				for _, t := range pending {
+					t.Stop()
+				}
+				fmt.Fprintln(os.Stderr)
+				output.PrintInfo("Stopped.")
+				return nil
+			}
+		}
+	},
+}
+
+// processWatchedFile uploads a file in the watched folder and moves it to
+// done/ or failed/. Files of other types are skipped. It only returns an
+// error if the upload could not be recorded in the state file; the file is
+// then left in place and the watcher must stop, since it could no longer
+// tell uploaded files apart.
+func processWatchedFile(client *api.Client, endpoint, dir, path string, state *watchState) error {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") || !hasExtension(name, inboxWatchExtensions) {
+		return nil
+	}
+
+	hash, err := fileHash(path)
+	if err != nil {
+		output.PrintError(fmt.Sprintf("%s: %v", name, err))
+		return nil
+	}
+
+	if prev, ok := state.Uploaded[hash]; ok {
+		output.PrintInfo(fmt.Sprintf("%s was already uploaded as %s on %s; moving to done/",
+			name, prev.Name, prev.UploadedAt.Format("2006-01-02 15:04")))
+		moveWatchedFile(dir, path, "done")
+		return nil
+	}
+
+	if err := uploadFile(client, endpoint, path, map[string]string{"name": name}); err != nil {
+		if errors.Is(err, context.Canceled) {
+			// Interrupted; leave the file for the next run.
+			return nil
+		}
+		output.PrintError(fmt.Sprintf("%s: upload failed: %v", name, err))
+		moveWatchedFile(dir, path, "failed")
+		return nil
+	}
+
+	state.Uploaded[hash] = watchUpload{Name: name, UploadedAt: time.Now()}
+	if err := state.save(); err != nil {
+		return fmt.Errorf("%s was uploaded, but saving the state file failed (the file was left in place): %w", name, err)
+	}
+	output.PrintSuccess(fmt.Sprintf("Uploaded %s", name))
+	moveWatchedFile(dir, path, "done")
+	return nil
+}
+
+// moveWatchedFile moves path into the sub folder of dir, adding a time
+// stamp to the name if a file with the same name is already there.
+func moveWatchedFile(dir, path, sub string) {
+	name := filepath.Base(path)
+	target := filepath.Join(dir, sub, name)
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(name)
+		target = filepath.Join(dir, sub, fmt.Sprintf("%s-%s%s",
+			strings.TrimSuffix(name, ext), time.Now().Format("20060102-150405"), ext))
+	}
+	if err := os.Rename(path, target); err != nil {
+		output.PrintError(fmt.Sprintf("moving %s to %s/: %v", name, sub, err))
+	}
+}
+
+// loadWatchState reads the state file at path. A missing file gives an
+// empty state.
+func loadWatchState(path string) (*watchState, error) {
+	state := &watchState{path: path, Uploaded: map[string]watchUpload{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading state file: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing state file %s: %w", path, err)
+	}
+	if state.Uploaded == nil {
+		state.Uploaded = map[string]watchUpload{}
+	}
+	return state, nil
+}
+
+// save writes the state file, replacing it atomically.
+func (s *watchState) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// fileHash returns the hex SHA-256 of the file at path.
+func fileHash(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hasExtension reports whether name ends in one of exts, ignoring case.
+func hasExtension(name string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range exts {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	inboxWatchCmd.Flags().StringVar(&inboxWatchState, "state", "", "State file (default <dir>/"+inboxWatchStateFile+")")
+	inboxWatchCmd.Flags().BoolVar(&inboxWatchOnce, "once", false, "Upload the files already in the folder and exit")
+	inboxWatchCmd.Flags().DurationVar(&inboxWatchSettle, "settle", 2*time.Second, "How long a file must be unchanged before it is uploaded")
+
+	inboxCmd.AddCommand(inboxWatchCmd)
+}
@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newWatchDir returns a temp folder with the done/ and failed/ subfolders
+// inbox watch creates.
+func newWatchDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, sub := range []string{"done", "failed"} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// listDir returns the names of the regular files in dir.
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestProcessWatchedFile(t *testing.T) {
+	var uploads []string
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		name := r.FormValue("name")
+		uploads = append(uploads, name)
+		if name == "rejected.pdf" {
+			http.Error(w, `{"error":"bad file"}`, http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	endpoint := "/companies/acme/inbox"
+
+	dir := newWatchDir(t)
+	state, err := loadWatchState(filepath.Join(dir, inboxWatchStateFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"scan.pdf":     "scan",
+		"copy.PDF":     "scan", // same content as scan.pdf
+		"rejected.pdf": "rejected",
+		"notes.txt":    "notes",
+		".hidden.pdf":  "hidden",
+	}
+	for _, name := range []string{"scan.pdf", "copy.PDF", "rejected.pdf", "notes.txt", ".hidden.pdf"} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(files[name]), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := processWatchedFile(client, endpoint, dir, path, state); err != nil {
+			t.Fatalf("processing %s: %v", name, err)
+		}
+	}
+
+	if want := []string{"scan.pdf", "rejected.pdf"}; len(uploads) != 2 || uploads[0] != want[0] || uploads[1] != want[1] {
+		t.Errorf("uploaded %v, want %v", uploads, want)
+	}
+	if got := listDir(t, filepath.Join(dir, "done")); len(got) != 2 || got[0] != "copy.PDF" || got[1] != "scan.pdf" {
+		t.Errorf("done/ has %v, want [copy.PDF scan.pdf]", got)
+	}
+	if got := listDir(t, filepath.Join(dir, "failed")); len(got) != 1 || got[0] != "rejected.pdf" {
+		t.Errorf("failed/ has %v, want [rejected.pdf]", got)
+	}
+	if got := listDir(t, dir); len(got) != 3 || got[0] != ".fiken-inbox-state.json" || got[1] != ".hidden.pdf" || got[2] != "notes.txt" {
+		t.Errorf("watched folder has %v, want the state file and the skipped files", got)
+	}
+
+	saved, err := loadWatchState(state.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(saved.Uploaded) != 1 {
+		t.Fatalf("state has %d uploads, want 1", len(saved.Uploaded))
+	}
+	for _, u := range saved.Uploaded {
+		if u.Name != "scan.pdf" {
+			t.Errorf("state records %q, want scan.pdf", u.Name)
+		}
+	}
+}
+
+func TestProcessWatchedFileSaveError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	dir := newWatchDir(t)
+	state, err := loadWatchState(filepath.Join(dir, "missing", "state.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "scan.pdf")
+	if err := os.WriteFile(path, []byte("scan"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := processWatchedFile(client, "/companies/acme/inbox", dir, path, state); err == nil {
+		t.Fatal("expected an error when the state file cannot be saved")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("file was not left in place: %v", err)
+	}
+	if got := listDir(t, filepath.Join(dir, "done")); len(got) != 0 {
+		t.Errorf("done/ has %v, want it empty", got)
+	}
+}
+
+func TestLoadWatchState(t *testing.T) {
+	dir := t.TempDir()
+
+	state, err := loadWatchState(filepath.Join(dir, "missing.json"))
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if state.Uploaded == nil || len(state.Uploaded) != 0 {
+		t.Errorf("missing file: Uploaded = %v, want an empty map", state.Uploaded)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{"empty object", `{}`, 0, false},
+		{"null uploads", `{"uploaded":null}`, 0, false},
+		{"uploads", `{"uploaded":{"abc":{"name":"scan.pdf","uploadedAt":"2024-03-01T10:00:00Z"}}}`, 1, false},
+		{"invalid", `{"uploaded":`, 0, true},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, "state.json")
+		if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		state, err := loadWatchState(path)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if state.Uploaded == nil || len(state.Uploaded) != tt.want {
+			t.Errorf("%s: Uploaded = %v, want %d entries", tt.name, state.Uploaded, tt.want)
+		}
+	}
+
+	// A saved state loads back unchanged.
+	path := filepath.Join(dir, "roundtrip.json")
+	at := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	orig := &watchState{path: path, Uploaded: map[string]watchUpload{"abc": {Name: "scan.pdf", UploadedAt: at}}}
+	if err := orig.save(); err != nil {
+		t.Fatal(err)
+	}
+	state, err = loadWatchState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u := state.Uploaded["abc"]; u.Name != "scan.pdf" || !u.UploadedAt.Equal(at) {
+		t.Errorf("round trip: got %+v", state.Uploaded)
+	}
+}
+
+func TestMoveWatchedFile(t *testing.T) {
+	dir := newWatchDir(t)
+	for i, content := range []string{"first", "second"} {
+		path := filepath.Join(dir, "scan.pdf")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		moveWatchedFile(dir, path, "done")
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("move %d: file still in the watched folder", i+1)
+		}
+	}
+
+	got := listDir(t, filepath.Join(dir, "done"))
+	if len(got) != 2 {
+		t.Fatalf("done/ has %v, want two files", got)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "done", "scan.pdf"))
+	if err != nil || string(data) != "first" {
+		t.Errorf("done/scan.pdf = %q, %v; want the first file", data, err)
+	}
+	renamed := got[0]
+	if renamed == "scan.pdf" {
+		renamed = got[1]
+	}
+	if filepath.Ext(renamed) != ".pdf" || len(renamed) <= len("scan.pdf") {
+		t.Errorf("second file was moved to %q, want a time stamped .pdf name", renamed)
+	}
+}
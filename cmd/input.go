@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readInputFile decodes a JSON or YAML file into v. YAML is detected by the
+// .yaml/.yml extension; a path of "-" reads JSON or YAML from stdin.
+// Field names follow the json tags of v in both formats.
+func readInputFile(path string, v interface{}) error {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	isJSON := ext == ".json" || (path == "-" && json.Valid(data))
+	if !isJSON {
+		// Decode YAML generically and round-trip through JSON so that
+		// the json struct tags apply to YAML input as well.
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		data, err = json.Marshal(normalizeYAML(generic))
+		if err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// normalizeYAML converts the timestamps that YAML produces for unquoted
+// dates back into YYYY-MM-DD strings, recursively.
+func normalizeYAML(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		if v.Equal(v.Truncate(24 * time.Hour)) {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339)
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = normalizeYAML(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+	}
+	return v
+}
+
+// parseAmount parses a kroner amount such as "1 234,50", "1234.50",
+// "1.234" or "-99" and returns it in øre (cents), which is what the Fiken
+// API uses.
+//
+// The last '.' or ',' is the decimal separator and the other one may
+// group thousands, except that a single kind of separator followed by
+// groups of exactly three digits, as in "1.234" or "1,234,567", groups
+// thousands. At most two decimals are allowed.
+func parseAmount(s string) (int64, error) {
+	orig := s
+	s = strings.NewReplacer(" ", "", "\u00a0", "", "_", "").Replace(strings.TrimSpace(s))
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if s == "" || strings.ContainsFunc(s, func(r rune) bool { return !strings.ContainsRune("0123456789.,", r) }) {
+		return 0, fmt.Errorf("invalid amount %q", orig)
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.LastIndexAny(s, ".,"); i >= 0 {
+		sep, other := s[i:i+1], "."
+		if sep == "." {
+			other = ","
+		}
+		if !strings.Contains(s, other) && thousandGroups(s, sep) {
+			intPart = strings.ReplaceAll(s, sep, "")
+		} else {
+			intPart, fracPart = s[:i], s[i+1:]
+			if strings.Contains(intPart, sep) || (strings.Contains(intPart, other) && !thousandGroups(intPart, other)) {
+				return 0, fmt.Errorf("invalid amount %q", orig)
+			}
+			intPart = strings.ReplaceAll(intPart, other, "")
+		}
+	}
+
+	if intPart == "" && fracPart == "" {
+		return 0, fmt.Errorf("invalid amount %q", orig)
+	}
+	if len(fracPart) > 2 {
+		return 0, fmt.Errorf("invalid amount %q (at most two decimals)", orig)
+	}
+	for len(fracPart) < 2 {
+		fracPart += "0"
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+
+	kr, err := strconv.ParseUint(intPart, 10, 63)
+	if err != nil || kr > math.MaxInt64/100 {
+		return 0, fmt.Errorf("invalid amount %q", orig)
+	}
+	ore, err := strconv.ParseUint(fracPart, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", orig)
+	}
+
+	cents := int64(kr)*100 + int64(ore)
+	if negative {
+		cents = -cents
+	}
+	return cents, nil
+}
+
+// thousandGroups reports whether s is digits grouped in thousands by sep,
+// such as "1.234" or "12,345,678".
+func thousandGroups(s, sep string) bool {
+	groups := strings.Split(s, sep)
+	if len(groups) < 2 || len(groups[0]) < 1 || len(groups[0]) > 3 || groups[0][0] == '0' {
+		return false
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// amount is a kroner amount in an input file, stored in øre.
+// It accepts both JSON numbers and strings like "1 234,50". Thousands
+// grouping only applies to strings: the number 1.234 is an error rather
+// than 1234 kroner.
+type amount int64
+
+func (a *amount) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	} else if !isAmountNumber(s) {
+		return fmt.Errorf("invalid amount %s (at most two decimals)", s)
+	}
+	cents, err := parseAmount(s)
+	if err != nil {
+		return err
+	}
+	*a = amount(cents)
+	return nil
+}
+
+// isAmountNumber reports whether a JSON number is a plain decimal with at
+// most two decimals, such as 1250 or -99.5.
+func isAmountNumber(s string) bool {
+	intPart, fracPart, hasFrac := strings.Cut(strings.TrimPrefix(s, "-"), ".")
+	isDigits := func(s string) bool {
+		return s != "" && !strings.ContainsFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	}
+	return isDigits(intPart) && (!hasFrac || (isDigits(fracPart) && len(fracPart) <= 2))
+}
+
+// parseKeyValues parses a flag value of the form "key=value,key=value".
+// A comma not followed by a key is kept as part of the previous value,
+// so descriptions may contain commas.
+func parseKeyValues(s string) (map[string]string, error) {
+	values := map[string]string{}
+	var lastKey string
+	for _, part := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || strings.ContainsAny(key, " ") {
+			if lastKey == "" {
+				return nil, fmt.Errorf("invalid value %q (expected key=value pairs)", s)
+			}
+			values[lastKey] += "," + part
+			continue
+		}
+		key = strings.TrimSpace(key)
+		values[key] = strings.TrimSpace(value)
+		lastKey = key
+	}
+	return values, nil
+}
+
+// idOrName references a resource by numeric ID or by name. In input files
+// it may be given as a JSON number or a string.
+type idOrName string
+
+func (r *idOrName) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	*r = idOrName(s)
+	return nil
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "0", want: 0},
+		{in: "99", want: 9900},
+		{in: "-99", want: -9900},
+		{in: "1234.50", want: 123450},
+		{in: "1234,5", want: 123450},
+		{in: "1 234,50", want: 123450},
+		{in: "1 234,50", want: 123450},
+		{in: "1_000", want: 100000},
+		{in: "1.234,50", want: 123450},
+		{in: "1,234.50", want: 123450},
+		{in: "1.234", want: 123400},
+		{in: "1.234.567", want: 123456700},
+		{in: "1,234,567", want: 123456700},
+		{in: "12.345.678,90", want: 1234567890},
+		{in: ",50", want: 50},
+		{in: "0,05", want: 5},
+		{in: "  42  ", want: 4200},
+
+		{in: "", wantErr: true},
+		{in: " ", wantErr: true},
+		{in: "-", wantErr: true},
+		{in: "--5", wantErr: true},
+		{in: "+5", wantErr: true},
+		{in: "5-", wantErr: true},
+		{in: "1.-5", wantErr: true},
+		{in: "1,2a", wantErr: true},
+		{in: "abc", wantErr: true},
+		{in: ".", wantErr: true},
+		{in: "-,", wantErr: true},
+		{in: "0.125", wantErr: true},
+		{in: "1.2.34", wantErr: true},
+		{in: "1.23,45", wantErr: true},
+		{in: "12.34.56", wantErr: true},
+		{in: "99999999999999999999", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseAmount(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseAmount(%q) = %d, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseAmount(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseAmount(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    amount
+		wantErr bool
+	}{
+		{in: `1250`, want: 125000},
+		{in: `-99.5`, want: -9950},
+		{in: `0.05`, want: 5},
+		{in: `"1 234,50"`, want: 123450},
+		{in: `"1.234"`, want: 123400},
+		{in: `null`, want: 0},
+
+		{in: `1.234`, wantErr: true},
+		{in: `1.5e3`, wantErr: true},
+		{in: `1e-06`, wantErr: true},
+		{in: `"abc"`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var got amount
+		err := json.Unmarshal([]byte(tt.in), &got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("unmarshal %s = %d, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("unmarshal %s = %d, %v; want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseKeyValues(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			in:   "desc=Support,qty=5,price=950",
+			want: map[string]string{"desc": "Support", "qty": "5", "price": "950"},
+		},
+		{
+			in:   "desc= Support ,qty=5",
+			want: map[string]string{"desc": "Support", "qty": "5"},
+		},
+		{
+			// A space in the key marks the part as a continuation.
+			in:   "desc=Support, qty=5",
+			want: map[string]string{"desc": "Support, qty=5"},
+		},
+		{
+			in:   "desc=Consulting, March,price=1 234,50",
+			want: map[string]string{"desc": "Consulting, March", "price": "1 234,50"},
+		},
+		{
+			in:   "desc=a=b",
+			want: map[string]string{"desc": "a=b"},
+		},
+		{
+			in:   "desc=",
+			want: map[string]string{"desc": ""},
+		},
+		{in: "Support", wantErr: true},
+		{in: ",desc=x", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseKeyValues(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseKeyValues(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseKeyValues(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseKeyValues(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -2,25 +2,752 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	invoicesFrom     string
+	invoicesTo       string
+	invoicesDueFrom  string
+	invoicesDueTo    string
+	invoicesCustomer int64
+	invoicesProject  string
+	invoicesPaid     bool
+	invoicesUnpaid   bool
+	invoicesSettled  bool
+	invoicesLimit    int
+	invoicesPageSize int
+	invoicesPdfOut   string
+)
+
+// invoiceCreateInput holds the flags for invoices create.
+var invoiceCreateInput struct {
+	file            string
+	customer        string
+	contactPersonID int64
+	issueDate       string
+	dueDate         string
+	dueDays         int
+	bankAccount     string
+	currency        string
+	text            string
+	ourReference    string
+	yourReference   string
+	orderReference  string
+	project         string
+	lines           []string
+	products        []int64
+	send            string
+}
+
+// invoiceSendInput holds the delivery flags shared by the commands that send invoices.
+var invoiceSendInput struct {
+	method             string
+	recipientName      string
+	recipientEmail     string
+	message            string
+	includeAttachments bool
+}
+
+// invoiceSendMethods are the delivery methods accepted by Fiken.
+var invoiceSendMethods = []string{"email", "ehf", "efaktura", "sms", "letter", "auto"}
+
+// defaultInvoiceDueDays is used when neither the command nor the customer sets a due date.
+const defaultInvoiceDueDays = 14
+
+// invoiceSpec is the file format accepted by invoices create --file.
+type invoiceSpec struct {
+	Customer        idOrName          `json:"customer"`
+	ContactPersonId int64             `json:"contactPersonId"`
+	IssueDate       string            `json:"issueDate"`
+	DueDate         string            `json:"dueDate"`
+	DueDays         int               `json:"dueDays"`
+	BankAccount     string            `json:"bankAccount"`
+	PaymentAccount  string            `json:"paymentAccount"` // drafts only
+	Currency        string            `json:"currency"`
+	InvoiceText     string            `json:"invoiceText"`
+	OurReference    string            `json:"ourReference"`
+	YourReference   string            `json:"yourReference"`
+	OrderReference  string            `json:"orderReference"`
+	Project         idOrName          `json:"project"`
+	Lines           []invoiceLineSpec `json:"lines"`
+	Send            string            `json:"send"`
+}
+
+// invoiceLineSpec is an invoice line in an input file or --line flag.
+// Amounts are in kroner. Product is a product ID whose defaults fill in
+// the fields left empty.
+type invoiceLineSpec struct {
+	Product       int64   `json:"product"`
+	Description   string  `json:"description"`
+	Comment       string  `json:"comment"`
+	Quantity      float64 `json:"quantity"`
+	UnitPrice     amount  `json:"unitPrice"`
+	Discount      int64   `json:"discount"`
+	VatType       string  `json:"vatType"`
+	IncomeAccount string  `json:"incomeAccount"`
+}
+
 var invoicesCmd = &cobra.Command{
 	Use:   "invoices",
 	Short: "Manage invoices",
+	Long:  "List, inspect, create, send and download invoices.",
 }
 
 var invoicesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List invoices",
+	Example: `  fiken invoices list --from 2024-01-01 --to 2024-01-31
+  fiken invoices list --unpaid --due-to 2024-02-15
+  fiken invoices list --customer 123 --settled=false`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if invoicesPaid && invoicesUnpaid {
+			return fmt.Errorf("--paid and --unpaid cannot be combined")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		dateFilters := []struct{ value, param string }{
+			{invoicesFrom, "issueDateGe"},
+			{invoicesTo, "issueDateLe"},
+			{invoicesDueFrom, "dueDateGe"},
+			{invoicesDueTo, "dueDateLe"},
+		}
+		for _, f := range dateFilters {
+			if f.value == "" {
+				continue
+			}
+			if err := validateDate(f.value); err != nil {
+				return err
+			}
+			params.Set(f.param, f.value)
+		}
+		if invoicesCustomer != 0 {
+			params.Set("customerId", strconv.FormatInt(invoicesCustomer, 10))
+		}
+		if cmd.Flags().Changed("settled") {
+			params.Set("settled", strconv.FormatBool(invoicesSettled))
+		}
+
+		projectFilter, err := resolveProjectID(client, slug, invoicesProject)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointInvoices, slug)
+
+		// Paid status and project are filtered locally, so the limit is applied afterwards.
+		localFilter := invoicesPaid || invoicesUnpaid || projectFilter != 0
+		limit := invoicesLimit
+		if localFilter {
+			limit = 0
+		}
+		invoices, err := fetchPages[api.Invoice](client, endpoint, params, invoicesPageSize, limit)
+		if err != nil {
+			return fmt.Errorf("fetching invoices: %w", err)
+		}
+
+		if localFilter {
+			invoices = filterItems(invoices, func(inv api.Invoice) bool {
+				if (invoicesPaid || invoicesUnpaid) && inv.Paid != invoicesPaid {
+					return false
+				}
+				return projectFilter == 0 || projectIDOf(inv.Project) == projectFilter
+			}, invoicesLimit)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(invoices)
+		}
+
+		if len(invoices) == 0 {
+			output.PrintInfo("No invoices found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "ISSUED", "DUE", "CUSTOMER", "GROSS", "CURRENCY", "PAID")
+		var totalGross int64
+		for _, inv := range invoices {
+			totalGross += inv.Gross
+			table.AddRow(
+				fmt.Sprintf("%d", inv.InvoiceId),
+				fmt.Sprintf("%d", inv.InvoiceNumber),
+				inv.IssueDate,
+				inv.DueDate,
+				inv.Customer.Name,
+				output.FormatAmount(inv.Gross),
+				inv.Currency,
+				yesNo(inv.Paid),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d invoices, total gross %s\n", len(invoices), output.FormatAmount(totalGross))
+		return nil
+	},
+}
+
+var invoicesGetCmd = &cobra.Command{
+	Use:   "get <invoiceId>",
+	Short: "Show an invoice with all lines",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		invoiceID, err := parseID(args[0], "invoice")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var invoice api.Invoice
+		_, err = client.Get(fmt.Sprintf(api.EndpointInvoice, slug, invoiceID), &invoice)
+		if err != nil {
+			return fmt.Errorf("fetching invoice: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(invoice)
+		}
+
+		printInvoice(invoice)
+		return nil
+	},
+}
+
+var invoicesPdfCmd = &cobra.Command{
+	Use:   "pdf <invoiceId>",
+	Short: "Download the invoice PDF",
+	Args:  cobra.ExactArgs(1),
+	Example: `  fiken invoices pdf 123 -o invoice.pdf
+  fiken invoices pdf 123 -o - > invoice.pdf`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		invoiceID, err := parseID(args[0], "invoice")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var invoice api.Invoice
+		_, err = client.Get(fmt.Sprintf(api.EndpointInvoice, slug, invoiceID), &invoice)
+		if err != nil {
+			return fmt.Errorf("fetching invoice: %w", err)
+		}
+
+		if invoice.InvoicePdf == nil || invoice.InvoicePdf.DownloadUrl == "" {
+			return fmt.Errorf("invoice %d has no PDF available", invoiceID)
+		}
+
+		path := invoicesPdfOut
+		if path == "" {
+			path = fmt.Sprintf("invoice-%d.pdf", invoice.InvoiceNumber)
+		}
+
+		if err := downloadTo(client, invoice.InvoicePdf.DownloadUrl, path); err != nil {
+			return fmt.Errorf("downloading invoice PDF: %w", err)
+		}
+
+		if path != "-" {
+			output.PrintSuccess(fmt.Sprintf("Saved invoice %d to %s", invoice.InvoiceNumber, path))
+		}
+		return nil
+	},
+}
+
+var invoicesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an invoice",
+	Long: `Create an invoice from flags, a JSON/YAML file, or both.
+
+Lines are given with --line as comma-separated key=value pairs:
+  description (desc), quantity (qty), unitPrice (price, in kroner),
+  vatType (vat, default HIGH), incomeAccount (account), comment, product,
+  discount (percent).
+A line with a product ID takes its description, price, VAT type and
+income account from the product unless they are given. --product <id>
+adds a line with one unit of a product.
+
+The customer can be given by contact ID or by name. The bank account
+defaults to the company's only active bank account, and the due date to
+the customer's payment terms (or 14 days).`,
+	Example: `  fiken invoices create --customer "Acme AS" \
+    --line "desc=Consulting January,qty=10,price=1200" --send email
+  fiken invoices create --customer 123 --product 42 --line "product=43,qty=3"
+  fiken invoices create --file invoice.yaml
+
+  # invoice.yaml
+  customer: Acme AS
+  dueDays: 14
+  lines:
+    - description: Consulting January
+      quantity: 10
+      unitPrice: 1200
+      vatType: HIGH
+      incomeAccount: "3000"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Not yet implemented.")
+		spec, err := readInvoiceSpec(cmd)
+		if err != nil {
+			return err
+		}
+
+		var methods []string
+		if spec.Send != "" {
+			methods, err = parseSendMethods(spec.Send)
+			if err != nil {
+				return err
+			}
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		req, err := buildInvoiceRequest(client, slug, spec)
+		if err != nil {
+			return err
+		}
+
+		invoiceID, err := client.PostForID(fmt.Sprintf(api.EndpointInvoices, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating invoice: %w", err)
+		}
+
+		if !jsonOutput {
+			output.PrintSuccess(fmt.Sprintf("Invoice created (ID %d)", invoiceID))
+		}
+
+		if len(methods) > 0 {
+			if err := sendInvoice(client, slug, invoiceID, methods); err != nil {
+				return err
+			}
+		}
+
+		if jsonOutput {
+			var invoice api.Invoice
+			_, err = client.Get(fmt.Sprintf(api.EndpointInvoice, slug, invoiceID), &invoice)
+			if err != nil {
+				return fmt.Errorf("fetching invoice: %w", err)
+			}
+			return output.PrintJSON(invoice)
+		}
 		return nil
 	},
 }
 
+var invoicesSendCmd = &cobra.Command{
+	Use:   "send <invoiceId>",
+	Short: "Send an invoice to the customer",
+	Long: `Send an invoice to the customer.
+
+The delivery method is one of: email, ehf, efaktura, sms, letter, auto.
+Several methods can be given comma-separated; Fiken tries them in order.`,
+	Args: cobra.ExactArgs(1),
+	Example: `  fiken invoices send 123 --method ehf,email
+  fiken invoices send 123 --method email --recipient-email faktura@acme.no`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		invoiceID, err := parseID(args[0], "invoice")
+		if err != nil {
+			return err
+		}
+		methods, err := parseSendMethods(invoiceSendInput.method)
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		return sendInvoice(client, slug, invoiceID, methods)
+	},
+}
+
+// applyInvoiceCreateFlags copies the invoices create flags that were set on
+// cmd into spec, overriding values from the input file.
+func applyInvoiceCreateFlags(cmd *cobra.Command, spec *invoiceSpec) error {
+	flags := cmd.Flags()
+	if flags.Changed("customer") {
+		spec.Customer = idOrName(invoiceCreateInput.customer)
+	}
+	if flags.Changed("contact-person") {
+		spec.ContactPersonId = invoiceCreateInput.contactPersonID
+	}
+	if flags.Changed("issue-date") {
+		spec.IssueDate = invoiceCreateInput.issueDate
+	}
+	if flags.Changed("due-date") {
+		spec.DueDate = invoiceCreateInput.dueDate
+	}
+	if flags.Changed("due-days") {
+		spec.DueDays = invoiceCreateInput.dueDays
+	}
+	if flags.Changed("bank-account") {
+		spec.BankAccount = invoiceCreateInput.bankAccount
+	}
+	if flags.Changed("currency") {
+		spec.Currency = invoiceCreateInput.currency
+	}
+	if flags.Changed("text") {
+		spec.InvoiceText = invoiceCreateInput.text
+	}
+	if flags.Changed("our-ref") {
+		spec.OurReference = invoiceCreateInput.ourReference
+	}
+	if flags.Changed("your-ref") {
+		spec.YourReference = invoiceCreateInput.yourReference
+	}
+	if flags.Changed("order-ref") {
+		spec.OrderReference = invoiceCreateInput.orderReference
+	}
+	if flags.Changed("project") {
+		spec.Project = idOrName(invoiceCreateInput.project)
+	}
+	if flags.Changed("send") {
+		spec.Send = invoiceCreateInput.send
+	}
+	for _, l := range invoiceCreateInput.lines {
+		line, err := parseInvoiceLine(l)
+		if err != nil {
+			return err
+		}
+		spec.Lines = append(spec.Lines, line)
+	}
+	for _, id := range invoiceCreateInput.products {
+		spec.Lines = append(spec.Lines, invoiceLineSpec{Product: id})
+	}
+	return nil
+}
+
+// parseInvoiceLine parses a --line flag value into an invoice line.
+func parseInvoiceLine(s string) (invoiceLineSpec, error) {
+	values, err := parseKeyValues(s)
+	if err != nil {
+		return invoiceLineSpec{}, err
+	}
+
+	var line invoiceLineSpec
+	for key, value := range values {
+		switch strings.ToLower(key) {
+		case "product":
+			line.Product, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return line, fmt.Errorf("invalid product ID %q in line %q", value, s)
+			}
+		case "description", "desc":
+			line.Description = value
+		case "comment":
+			line.Comment = value
+		case "quantity", "qty":
+			line.Quantity, err = strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+			if err != nil {
+				return line, fmt.Errorf("invalid quantity %q in line %q", value, s)
+			}
+		case "unitprice", "price":
+			cents, err := parseAmount(value)
+			if err != nil {
+				return line, err
+			}
+			line.UnitPrice = amount(cents)
+		case "discount":
+			line.Discount, err = strconv.ParseInt(strings.TrimSuffix(value, "%"), 10, 64)
+			if err != nil {
+				return line, fmt.Errorf("invalid discount %q in line %q", value, s)
+			}
+		case "vattype", "vat":
+			line.VatType = value
+		case "incomeaccount", "account":
+			line.IncomeAccount = value
+		default:
+			return line, fmt.Errorf("unknown key %q in line %q", key, s)
+		}
+	}
+	return line, nil
+}
+
+// buildInvoiceRequest resolves the customer, bank account and due date of
+// spec and converts it into an API request.
+func buildInvoiceRequest(client *api.Client, slug string, spec invoiceSpec) (api.InvoiceRequest, error) {
+	if spec.Customer == "" {
+		return api.InvoiceRequest{}, fmt.Errorf("a customer is required (--customer)")
+	}
+	if len(spec.Lines) == 0 {
+		return api.InvoiceRequest{}, fmt.Errorf("at least one line is required (--line)")
+	}
+
+	issueDate := spec.IssueDate
+	if issueDate == "" {
+		issueDate = time.Now().Format("2006-01-02")
+	}
+	if err := validateDate(issueDate); err != nil {
+		return api.InvoiceRequest{}, err
+	}
+
+	customer, err := resolveContact(client, slug, string(spec.Customer))
+	if err != nil {
+		return api.InvoiceRequest{}, err
+	}
+
+	dueDate := spec.DueDate
+	if dueDate == "" {
+		days := spec.DueDays
+		if days == 0 {
+			days = customer.DaysUntilInvoicingDueDate
+		}
+		if days == 0 {
+			days = defaultInvoiceDueDays
+		}
+		issued, _ := time.Parse("2006-01-02", issueDate)
+		dueDate = issued.AddDate(0, 0, days).Format("2006-01-02")
+	}
+	if err := validateDate(dueDate); err != nil {
+		return api.InvoiceRequest{}, err
+	}
+
+	bankAccountCode, err := resolveBankAccountCode(client, slug, spec.BankAccount)
+	if err != nil {
+		return api.InvoiceRequest{}, err
+	}
+
+	projectID, err := resolveProjectID(client, slug, string(spec.Project))
+	if err != nil {
+		return api.InvoiceRequest{}, err
+	}
+
+	req := api.InvoiceRequest{
+		IssueDate:       issueDate,
+		DueDate:         dueDate,
+		CustomerId:      customer.ContactId,
+		ContactPersonId: spec.ContactPersonId,
+		Currency:        spec.Currency,
+		InvoiceText:     spec.InvoiceText,
+		OurReference:    spec.OurReference,
+		YourReference:   spec.YourReference,
+		OrderReference:  spec.OrderReference,
+		BankAccountCode: bankAccountCode,
+		ProjectId:       projectID,
+	}
+	for i, l := range spec.Lines {
+		if l.Product != 0 {
+			product, err := fetchProduct(client, slug, l.Product)
+			if err != nil {
+				return api.InvoiceRequest{}, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			l = invoiceLineFromProduct(l, product)
+		}
+		if l.Description == "" {
+			return api.InvoiceRequest{}, fmt.Errorf("line %d: description is required", i+1)
+		}
+		quantity := l.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		vatType := l.VatType
+		if vatType == "" {
+			vatType = "HIGH"
+		}
+		req.Lines = append(req.Lines, api.InvoiceLineRequest{
+			ProductId:     l.Product,
+			Description:   l.Description,
+			Comment:       l.Comment,
+			Quantity:      quantity,
+			UnitPrice:     int64(l.UnitPrice),
+			Discount:      l.Discount,
+			VatType:       vatType,
+			IncomeAccount: l.IncomeAccount,
+		})
+	}
+	return req, nil
+}
+
+// parseSendMethods parses a comma-separated list of delivery methods.
+func parseSendMethods(s string) ([]string, error) {
+	var methods []string
+	for _, m := range strings.Split(s, ",") {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if m == "" {
+			continue
+		}
+		if !slices.Contains(invoiceSendMethods, m) {
+			return nil, fmt.Errorf("invalid delivery method %q (valid: %s)", m, strings.Join(invoiceSendMethods, ", "))
+		}
+		methods = append(methods, m)
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no delivery method given")
+	}
+	return methods, nil
+}
+
+// sendInvoice sends an invoice using the delivery flags in invoiceSendInput.
+func sendInvoice(client *api.Client, slug string, invoiceID int64, methods []string) error {
+	req := api.SendInvoiceRequest{
+		InvoiceId:                  invoiceID,
+		Method:                     methods,
+		IncludeDocumentAttachments: invoiceSendInput.includeAttachments,
+		RecipientName:              invoiceSendInput.recipientName,
+		RecipientEmail:             invoiceSendInput.recipientEmail,
+		Message:                    invoiceSendInput.message,
+	}
+	if _, err := client.Post(fmt.Sprintf(api.EndpointInvoicesSend, slug), req, nil); err != nil {
+		return fmt.Errorf("sending invoice %d: %w", invoiceID, err)
+	}
+	if !jsonOutput {
+		output.PrintSuccess(fmt.Sprintf("Invoice %d sent (%s)", invoiceID, strings.Join(methods, ", ")))
+	}
+	return nil
+}
+
+// addInvoiceSpecFlags registers the flags that make up an invoiceSpec.
+func addInvoiceSpecFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringVarP(&invoiceCreateInput.file, "file", "f", "", "Read invoice from a JSON or YAML file (- for stdin)")
+	f.StringVar(&invoiceCreateInput.customer, "customer", "", "Customer contact ID or name")
+	f.Int64Var(&invoiceCreateInput.contactPersonID, "contact-person", 0, "Contact person ID at the customer")
+	f.StringVar(&invoiceCreateInput.issueDate, "issue-date", "", "Issue date (YYYY-MM-DD, default today)")
+	f.StringVar(&invoiceCreateInput.dueDate, "due-date", "", "Due date (YYYY-MM-DD)")
+	f.IntVar(&invoiceCreateInput.dueDays, "due-days", 0, "Days until due (default from customer, or 14)")
+	f.StringVar(&invoiceCreateInput.bankAccount, "bank-account", "", "Bank account code, ID, number or name")
+	f.StringVar(&invoiceCreateInput.currency, "currency", "", "Currency (default NOK)")
+	f.StringVar(&invoiceCreateInput.text, "text", "", "Invoice text")
+	f.StringVar(&invoiceCreateInput.ourReference, "our-ref", "", "Our reference")
+	f.StringVar(&invoiceCreateInput.yourReference, "your-ref", "", "Your reference")
+	f.StringVar(&invoiceCreateInput.orderReference, "order-ref", "", "Order reference")
+	f.StringVar(&invoiceCreateInput.project, "project", "", "Project ID, number or name")
+	f.StringArrayVar(&invoiceCreateInput.lines, "line", nil, "Invoice line as key=value pairs (repeatable)")
+	f.Int64SliceVar(&invoiceCreateInput.products, "product", nil, "Add a line for a product ID with its defaults (repeatable)")
+}
+
+// addSendFlags registers the recipient flags used when sending documents.
+func addSendFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&invoiceSendInput.recipientName, "recipient-name", "", "Override recipient name")
+	cmd.Flags().StringVar(&invoiceSendInput.recipientEmail, "recipient-email", "", "Override recipient email address")
+	cmd.Flags().StringVar(&invoiceSendInput.message, "message", "", "Message to include in the email")
+	cmd.Flags().BoolVar(&invoiceSendInput.includeAttachments, "include-attachments", false, "Include document attachments")
+}
+
+func printInvoice(inv api.Invoice) {
+	d := output.NewDetails()
+	d.Add("ID", fmt.Sprintf("%d", inv.InvoiceId))
+	d.Add("Number", fmt.Sprintf("%d", inv.InvoiceNumber))
+	d.Add("Customer", inv.Customer.Name)
+	d.Add("Project", projectLabel(inv.Project))
+	d.Add("Issue date", inv.IssueDate)
+	d.Add("Due date", inv.DueDate)
+	d.Add("KID", inv.Kid)
+	d.Add("Our reference", inv.OurReference)
+	d.Add("Your reference", inv.YourReference)
+	d.Add("Order reference", inv.OrderReference)
+	d.Add("Bank account", inv.BankAccountNumber)
+	d.Add("Currency", inv.Currency)
+	d.Add("Paid", yesNo(inv.Paid))
+	d.Add("Settled", yesNo(inv.Settled))
+	d.Add("Text", inv.InvoiceText)
+	d.Print()
+
+	fmt.Println()
+	printInvoiceLines(inv.Lines)
+
+	fmt.Println()
+	totals := output.NewDetails()
+	totals.Add("Net", output.FormatAmount(inv.Net))
+	totals.Add("VAT", output.FormatAmount(inv.Vat))
+	totals.Add("Gross", output.FormatAmount(inv.Gross))
+	totals.Print()
+}
+
+func printInvoiceLines(lines []api.InvoiceLine) {
+	table := output.NewTable("DESCRIPTION", "QTY", "UNIT PRICE", "NET", "VAT", "GROSS", "VAT TYPE")
+	for _, l := range lines {
+		description := l.Description
+		if description == "" {
+			description = l.ProductName
+		}
+		table.AddRow(
+			description,
+			strconv.FormatFloat(l.Quantity, 'f', -1, 64),
+			output.FormatAmount(l.UnitPrice),
+			output.FormatAmount(l.Net),
+			output.FormatAmount(l.Vat),
+			output.FormatAmount(l.Gross),
+			l.VatType,
+		)
+	}
+	table.Print()
+}
+
 func init() {
+	invoicesListCmd.Flags().StringVar(&invoicesFrom, "from", "", "Issue date from (YYYY-MM-DD)")
+	invoicesListCmd.Flags().StringVar(&invoicesTo, "to", "", "Issue date to (YYYY-MM-DD)")
+	invoicesListCmd.Flags().StringVar(&invoicesDueFrom, "due-from", "", "Due date from (YYYY-MM-DD)")
+	invoicesListCmd.Flags().StringVar(&invoicesDueTo, "due-to", "", "Due date to (YYYY-MM-DD)")
+	invoicesListCmd.Flags().Int64Var(&invoicesCustomer, "customer", 0, "Filter by customer contact ID")
+	invoicesListCmd.Flags().StringVar(&invoicesProject, "project", "", "Filter by project ID, number or name")
+	invoicesListCmd.Flags().BoolVar(&invoicesPaid, "paid", false, "Only paid invoices")
+	invoicesListCmd.Flags().BoolVar(&invoicesUnpaid, "unpaid", false, "Only unpaid invoices")
+	invoicesListCmd.Flags().BoolVar(&invoicesSettled, "settled", false, "Filter by settled status (--settled=false for unsettled)")
+	invoicesListCmd.Flags().IntVar(&invoicesLimit, "limit", 0, "Maximum number of invoices to show (0 = all)")
+	invoicesListCmd.Flags().IntVar(&invoicesPageSize, "page-size", api.MaxPageSize, "Number of invoices to fetch per request")
+
+	invoicesPdfCmd.Flags().StringVarP(&invoicesPdfOut, "output", "o", "", "Output file (default invoice-<number>.pdf, - for stdout)")
+
+	addInvoiceSpecFlags(invoicesCreateCmd)
+	invoicesCreateCmd.Flags().StringVar(&invoiceCreateInput.send, "send", "", "Send right away: email, ehf, efaktura, sms, letter or auto")
+	addSendFlags(invoicesCreateCmd)
+
+	invoicesSendCmd.Flags().StringVar(&invoiceSendInput.method, "method", "auto", "Delivery method(s): email, ehf, efaktura, sms, letter, auto")
+	addSendFlags(invoicesSendCmd)
+
 	invoicesCmd.AddCommand(invoicesListCmd)
+	invoicesCmd.AddCommand(invoicesGetCmd)
+	invoicesCmd.AddCommand(invoicesPdfCmd)
+	invoicesCmd.AddCommand(invoicesCreateCmd)
+	invoicesCmd.AddCommand(invoicesSendCmd)
+	invoicesCmd.AddCommand(newDraftsCmd(invoiceDraftKind))
+	invoicesCmd.AddCommand(newAttachmentsCmd("invoice", "invoices", api.EndpointInvoiceAttachments))
 	rootCmd.AddCommand(invoicesCmd)
 }
@@ -2,25 +2,506 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	journalFrom     string
+	journalTo       string
+	journalAccount  string
+	journalProject  string
+	journalLimit    int
+	journalPageSize int
+)
+
+// journalCreateInput holds the flags for journal create.
+var journalCreateInput struct {
+	file        string
+	date        string
+	description string
+	debits      []string
+	credits     []string
+	lines       []string
+	project     string
+}
+
+// journalSpec is the file format accepted by journal create --file.
+type journalSpec struct {
+	Date        string            `json:"date"`
+	Description string            `json:"description"`
+	Project     idOrName          `json:"project"`
+	Lines       []journalLineSpec `json:"lines"`
+}
+
+// journalLineSpec is a journal line in an input file or flag. The amount
+// (in kroner) is debited to Debit and/or credited to Credit.
+type journalLineSpec struct {
+	Debit         string `json:"debit"`
+	Credit        string `json:"credit"`
+	Amount        amount `json:"amount"`
+	DebitVatCode  string `json:"debitVatCode"`
+	CreditVatCode string `json:"creditVatCode"`
+}
+
 var journalCmd = &cobra.Command{
 	Use:   "journal",
 	Short: "Manage journal entries",
+	Long:  "List, inspect and create general journal entries (vouchers).",
 }
 
 var journalListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List journal entries",
+	Example: `  fiken journal list --from 2024-01-01 --to 2024-03-31
+  fiken journal list --account 1920 --limit 20`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if journalFrom != "" {
+			if err := validateDate(journalFrom); err != nil {
+				return err
+			}
+			params.Set("dateGe", journalFrom)
+		}
+		if journalTo != "" {
+			if err := validateDate(journalTo); err != nil {
+				return err
+			}
+			params.Set("dateLe", journalTo)
+		}
+
+		projectFilter, err := resolveProjectID(client, slug, journalProject)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointJournalEntries, slug)
+
+		// The account and project filters are applied locally, so the limit is applied afterwards.
+		localFilter := journalAccount != "" || projectFilter != 0
+		limit := journalLimit
+		if localFilter {
+			limit = 0
+		}
+		entries, err := fetchPages[api.JournalEntry](client, endpoint, params, journalPageSize, limit)
+		if err != nil {
+			return fmt.Errorf("fetching journal entries: %w", err)
+		}
+
+		if localFilter {
+			entries = filterItems(entries, func(e api.JournalEntry) bool {
+				if journalAccount != "" && !journalEntryUsesAccount(e, journalAccount) {
+					return false
+				}
+				return projectFilter == 0 || journalEntryHasProject(e, projectFilter)
+			}, journalLimit)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(entries)
+		}
+
+		if len(entries) == 0 {
+			output.PrintInfo("No journal entries found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "DATE", "DESCRIPTION", "AMOUNT")
+		for _, e := range entries {
+			number := ""
+			if e.JournalEntryNumber != 0 {
+				number = fmt.Sprintf("%d", e.JournalEntryNumber)
+			}
+			debit, _ := journalTotals(e.Lines)
+			table.AddRow(
+				fmt.Sprintf("%d", e.JournalEntryId),
+				number,
+				e.Date,
+				e.Description,
+				output.FormatAmount(debit),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d journal entries\n", len(entries))
+		return nil
+	},
+}
+
+var journalGetCmd = &cobra.Command{
+	Use:   "get <journalEntryId>",
+	Short: "Show a journal entry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entryID, err := parseID(args[0], "journal entry")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var entry api.JournalEntry
+		_, err = client.Get(fmt.Sprintf(api.EndpointJournalEntry, slug, entryID), &entry)
+		if err != nil {
+			return fmt.Errorf("fetching journal entry: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(entry)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", entry.JournalEntryId))
+		if entry.JournalEntryNumber != 0 {
+			d.Add("Number", fmt.Sprintf("%d", entry.JournalEntryNumber))
+		}
+		d.Add("Date", entry.Date)
+		d.Add("Description", entry.Description)
+		if entry.TransactionId != 0 {
+			d.Add("Transaction ID", fmt.Sprintf("%d", entry.TransactionId))
+		}
+		d.Print()
+
+		fmt.Println()
+		printJournalLines(entry.Lines)
+
+		if len(entry.Attachments) > 0 {
+			fmt.Println()
+			printAttachments(entry.Attachments)
+		}
+		return nil
+	},
+}
+
+var journalCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a general journal entry",
+	Long: `Create a general journal entry (voucher) from flags, a JSON/YAML file, or both.
+
+Amounts are in kroner. Use --debit and --credit with ACCOUNT=AMOUNT for
+one-sided lines, or --line with the keys debit, credit, amount,
+debitVatCode and creditVatCode for lines that debit one account and credit
+another. Debits must equal credits, and every account must exist in the
+chart of accounts, before the entry is posted.`,
+	Example: `  # Accrue December rent
+  fiken journal create --date 2024-12-31 --description "Accrued rent December" \
+    --debit 6300=12000 --credit 2960=12000
+
+  # Monthly depreciation of equipment
+  fiken journal create --date 2024-01-31 --description "Depreciation January" \
+    --line "debit=6010,credit=1209,amount=2500"
+
+  fiken journal create --file voucher.yaml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Not yet implemented.")
+		spec := journalSpec{}
+		if journalCreateInput.file != "" {
+			if err := readInputFile(journalCreateInput.file, &spec); err != nil {
+				return err
+			}
+		}
+		if err := applyJournalCreateFlags(cmd, &spec); err != nil {
+			return err
+		}
+
+		req, err := buildJournalRequest(spec)
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		projectID, err := resolveProjectID(client, slug, string(spec.Project))
+		if err != nil {
+			return err
+		}
+		for i := range req.JournalEntries[0].Lines {
+			req.JournalEntries[0].Lines[i].ProjectId = projectID
+		}
+
+		var accounts []string
+		for _, l := range req.JournalEntries[0].Lines {
+			for _, a := range []string{l.DebitAccount, l.CreditAccount} {
+				if a != "" {
+					accounts = append(accounts, a)
+				}
+			}
+		}
+		if err := validateAccounts(client, slug, accounts); err != nil {
+			return err
+		}
+
+		entryID, err := client.PostForID(fmt.Sprintf(api.EndpointGeneralJournalEntries, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating journal entry: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"journalEntryId": entryID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Journal entry created (ID %d)", entryID))
 		return nil
 	},
 }
 
+// applyJournalCreateFlags copies the journal create flags that were set on
+// cmd into spec, overriding values from the input file. Lines from flags
+// are appended to lines from the file.
+func applyJournalCreateFlags(cmd *cobra.Command, spec *journalSpec) error {
+	flags := cmd.Flags()
+	if flags.Changed("date") {
+		spec.Date = journalCreateInput.date
+	}
+	if flags.Changed("description") {
+		spec.Description = journalCreateInput.description
+	}
+	if flags.Changed("project") {
+		spec.Project = idOrName(journalCreateInput.project)
+	}
+	for _, s := range journalCreateInput.debits {
+		account, cents, err := parseAccountAmount(s)
+		if err != nil {
+			return fmt.Errorf("--debit: %w", err)
+		}
+		spec.Lines = append(spec.Lines, journalLineSpec{Debit: account, Amount: cents})
+	}
+	for _, s := range journalCreateInput.credits {
+		account, cents, err := parseAccountAmount(s)
+		if err != nil {
+			return fmt.Errorf("--credit: %w", err)
+		}
+		spec.Lines = append(spec.Lines, journalLineSpec{Credit: account, Amount: cents})
+	}
+	for _, s := range journalCreateInput.lines {
+		line, err := parseJournalLine(s)
+		if err != nil {
+			return err
+		}
+		spec.Lines = append(spec.Lines, line)
+	}
+	return nil
+}
+
+// parseAccountAmount parses an ACCOUNT=AMOUNT flag value.
+func parseAccountAmount(s string) (string, amount, error) {
+	account, value, ok := strings.Cut(s, "=")
+	account = strings.TrimSpace(account)
+	if !ok || account == "" {
+		return "", 0, fmt.Errorf("invalid value %q (expected ACCOUNT=AMOUNT)", s)
+	}
+	cents, err := parseAmount(strings.TrimSpace(value))
+	if err != nil {
+		return "", 0, err
+	}
+	return account, amount(cents), nil
+}
+
+// parseJournalLine parses a --line flag value into a journal line.
+// Keys: debit, credit, amount, debitVatCode, creditVatCode.
+func parseJournalLine(s string) (journalLineSpec, error) {
+	values, err := parseKeyValues(s)
+	if err != nil {
+		return journalLineSpec{}, err
+	}
+
+	var line journalLineSpec
+	for key, value := range values {
+		switch strings.ToLower(key) {
+		case "debit":
+			line.Debit = value
+		case "credit":
+			line.Credit = value
+		case "debitvatcode":
+			line.DebitVatCode = value
+		case "creditvatcode":
+			line.CreditVatCode = value
+		case "amount":
+			cents, err := parseAmount(value)
+			if err != nil {
+				return line, err
+			}
+			line.Amount = amount(cents)
+		default:
+			return line, fmt.Errorf("unknown key %q in line %q", key, s)
+		}
+	}
+	return line, nil
+}
+
+// buildJournalRequest validates spec and converts it into an API request.
+// It checks that every line has an account and a positive amount and that
+// debits equal credits.
+func buildJournalRequest(spec journalSpec) (api.GeneralJournalRequest, error) {
+	if spec.Description == "" {
+		return api.GeneralJournalRequest{}, fmt.Errorf("a description is required (--description)")
+	}
+	if len(spec.Lines) == 0 {
+		return api.GeneralJournalRequest{}, fmt.Errorf("at least one line is required (--debit/--credit or --line)")
+	}
+
+	date := spec.Date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if err := validateDate(date); err != nil {
+		return api.GeneralJournalRequest{}, err
+	}
+
+	entry := api.JournalEntryRequest{
+		Description: spec.Description,
+		Date:        date,
+	}
+	for i, l := range spec.Lines {
+		if l.Debit == "" && l.Credit == "" {
+			return api.GeneralJournalRequest{}, fmt.Errorf("line %d: a debit or credit account is required", i+1)
+		}
+		if l.Amount <= 0 {
+			return api.GeneralJournalRequest{}, fmt.Errorf("line %d: amount must be positive", i+1)
+		}
+		entry.Lines = append(entry.Lines, api.JournalLine{
+			Amount:        int64(l.Amount),
+			DebitAccount:  l.Debit,
+			DebitVatCode:  l.DebitVatCode,
+			CreditAccount: l.Credit,
+			CreditVatCode: l.CreditVatCode,
+		})
+	}
+
+	debit, credit := journalTotals(entry.Lines)
+	if debit != credit {
+		return api.GeneralJournalRequest{}, fmt.Errorf("debits (%s) do not equal credits (%s); difference %s",
+			output.FormatAmount(debit), output.FormatAmount(credit), output.FormatAmount(debit-credit))
+	}
+
+	return api.GeneralJournalRequest{
+		Description:    spec.Description,
+		JournalEntries: []api.JournalEntryRequest{entry},
+	}, nil
+}
+
+func printJournalLines(lines []api.JournalLine) {
+	table := output.NewTable("DEBIT", "CREDIT", "AMOUNT", "VAT CODE")
+	for _, l := range lines {
+		debit, credit := journalLineAccounts(l)
+		vatCode := l.VatCode
+		if vatCode == "" {
+			vatCode = strings.Trim(l.DebitVatCode+"/"+l.CreditVatCode, "/")
+		}
+		amount := l.Amount
+		if amount < 0 {
+			amount = -amount
+		}
+		table.AddRow(debit, credit, output.FormatAmount(amount), vatCode)
+	}
+	table.Print()
+}
+
+// journalLineAccounts returns the debit and credit account of a line. Lines
+// that use a single account with a signed amount are debits when the
+// amount is positive and credits when it is negative.
+func journalLineAccounts(l api.JournalLine) (debit, credit string) {
+	if l.Account == "" {
+		return l.DebitAccount, l.CreditAccount
+	}
+	if l.Amount < 0 {
+		return "", l.Account
+	}
+	return l.Account, ""
+}
+
+// journalTotals returns the total debit and credit amounts of lines.
+func journalTotals(lines []api.JournalLine) (debit, credit int64) {
+	for _, l := range lines {
+		d, c := journalLineAccounts(l)
+		amount := l.Amount
+		if amount < 0 {
+			amount = -amount
+		}
+		if d != "" {
+			debit += amount
+		}
+		if c != "" {
+			credit += amount
+		}
+	}
+	return debit, credit
+}
+
+// journalEntryHasProject reports whether any line of e is tagged with the
+// project.
+func journalEntryHasProject(e api.JournalEntry, projectID int64) bool {
+	for _, l := range e.Lines {
+		if l.ProjectId == projectID {
+			return true
+		}
+	}
+	return false
+}
+
+// journalEntryUsesAccount reports whether any line of e posts to account.
+// A main account such as 1500 also matches its subaccounts (1500:10001).
+func journalEntryUsesAccount(e api.JournalEntry, account string) bool {
+	for _, l := range e.Lines {
+		for _, a := range []string{l.Account, l.DebitAccount, l.CreditAccount} {
+			main, _, _ := strings.Cut(a, ":")
+			if a != "" && (a == account || main == account) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func init() {
+	journalListCmd.Flags().StringVar(&journalFrom, "from", "", "Date from (YYYY-MM-DD)")
+	journalListCmd.Flags().StringVar(&journalTo, "to", "", "Date to (YYYY-MM-DD)")
+	journalListCmd.Flags().StringVar(&journalAccount, "account", "", "Only entries posting to this account code")
+	journalListCmd.Flags().StringVar(&journalProject, "project", "", "Only entries tagged with this project ID, number or name")
+	journalListCmd.Flags().IntVar(&journalLimit, "limit", 0, "Maximum number of entries to show (0 = all)")
+	journalListCmd.Flags().IntVar(&journalPageSize, "page-size", api.MaxPageSize, "Number of entries to fetch per request")
+
+	f := journalCreateCmd.Flags()
+	f.StringVarP(&journalCreateInput.file, "file", "f", "", "Read journal entry from a JSON or YAML file (- for stdin)")
+	f.StringVar(&journalCreateInput.date, "date", "", "Voucher date (YYYY-MM-DD, default today)")
+	f.StringVar(&journalCreateInput.description, "description", "", "Voucher description (required)")
+	f.StringVar(&journalCreateInput.project, "project", "", "Project ID, number or name for all lines")
+	f.StringArrayVar(&journalCreateInput.debits, "debit", nil, "Debit ACCOUNT=AMOUNT (repeatable)")
+	f.StringArrayVar(&journalCreateInput.credits, "credit", nil, "Credit ACCOUNT=AMOUNT (repeatable)")
+	f.StringArrayVar(&journalCreateInput.lines, "line", nil, "Line as key=value pairs: debit, credit, amount, debitVatCode, creditVatCode (repeatable)")
+
 	journalCmd.AddCommand(journalListCmd)
+	journalCmd.AddCommand(journalGetCmd)
+	journalCmd.AddCommand(journalCreateCmd)
+	journalCmd.AddCommand(newAttachmentsCmd("journal entry", "journal", api.EndpointJournalEntryAttachments))
 	rootCmd.AddCommand(journalCmd)
 }
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+func TestBuildJournalRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    journalSpec
+		wantErr string
+	}{
+		{
+			name: "two-sided line",
+			spec: journalSpec{Date: "2024-01-31", Description: "Depreciation", Lines: []journalLineSpec{
+				{Debit: "6010", Credit: "1209", Amount: 250000},
+			}},
+		},
+		{
+			name: "one-sided lines that balance",
+			spec: journalSpec{Date: "2024-12-31", Description: "Accrued rent", Lines: []journalLineSpec{
+				{Debit: "6300", Amount: 1000000},
+				{Debit: "6340", Amount: 200000},
+				{Credit: "2960", Amount: 1200000},
+			}},
+		},
+		{
+			name: "unbalanced",
+			spec: journalSpec{Date: "2024-12-31", Description: "Oops", Lines: []journalLineSpec{
+				{Debit: "6300", Amount: 1000000},
+				{Credit: "2960", Amount: 999999},
+			}},
+			wantErr: "do not equal credits",
+		},
+		{
+			name: "only debits",
+			spec: journalSpec{Description: "Oops", Lines: []journalLineSpec{
+				{Debit: "6300", Amount: 100},
+			}},
+			wantErr: "do not equal credits",
+		},
+		{
+			name:    "no description",
+			spec:    journalSpec{Lines: []journalLineSpec{{Debit: "6010", Credit: "1209", Amount: 100}}},
+			wantErr: "description is required",
+		},
+		{
+			name:    "no lines",
+			spec:    journalSpec{Description: "Empty"},
+			wantErr: "at least one line",
+		},
+		{
+			name:    "no account",
+			spec:    journalSpec{Description: "x", Lines: []journalLineSpec{{Amount: 100}}},
+			wantErr: "line 1: a debit or credit account is required",
+		},
+		{
+			name:    "zero amount",
+			spec:    journalSpec{Description: "x", Lines: []journalLineSpec{{Debit: "6010", Credit: "1209"}}},
+			wantErr: "line 1: amount must be positive",
+		},
+		{
+			name: "negative amount",
+			spec: journalSpec{Description: "x", Lines: []journalLineSpec{
+				{Debit: "6010", Credit: "1209", Amount: 100},
+				{Debit: "6010", Credit: "1209", Amount: -100},
+			}},
+			wantErr: "line 2: amount must be positive",
+		},
+		{
+			name:    "bad date",
+			spec:    journalSpec{Date: "31.12.2024", Description: "x", Lines: []journalLineSpec{{Debit: "6010", Credit: "1209", Amount: 100}}},
+			wantErr: "invalid date",
+		},
+	}
+	for _, tt := range tests {
+		req, err := buildJournalRequest(tt.spec)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error: %v", tt.name, err)
+			continue
+		}
+		if len(req.JournalEntries) != 1 || len(req.JournalEntries[0].Lines) != len(tt.spec.Lines) {
+			t.Errorf("%s: got %+v", tt.name, req)
+			continue
+		}
+		if req.JournalEntries[0].Date != tt.spec.Date {
+			t.Errorf("%s: date = %q, want %q", tt.name, req.JournalEntries[0].Date, tt.spec.Date)
+		}
+	}
+}
+
+func TestBuildJournalRequestDefaultsToToday(t *testing.T) {
+	req, err := buildJournalRequest(journalSpec{Description: "x", Lines: []journalLineSpec{
+		{Debit: "6010", Credit: "1209", Amount: 100},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.JournalEntries[0].Date, time.Now().Format("2006-01-02"); got != want {
+		t.Errorf("date = %q, want %q", got, want)
+	}
+}
+
+func TestJournalTotals(t *testing.T) {
+	tests := []struct {
+		name          string
+		lines         []api.JournalLine
+		debit, credit int64
+	}{
+		{
+			name:   "debit and credit account on one line",
+			lines:  []api.JournalLine{{Amount: 500, DebitAccount: "6010", CreditAccount: "1209"}},
+			debit:  500,
+			credit: 500,
+		},
+		{
+			name: "signed amounts on a single account",
+			lines: []api.JournalLine{
+				{Amount: 1200, Account: "6300"},
+				{Amount: -1200, Account: "2960"},
+			},
+			debit:  1200,
+			credit: 1200,
+		},
+		{
+			name: "mixed",
+			lines: []api.JournalLine{
+				{Amount: 300, DebitAccount: "6300"},
+				{Amount: -300, Account: "1920"},
+				{Amount: 50, CreditAccount: "2700"},
+			},
+			debit:  300,
+			credit: 350,
+		},
+	}
+	for _, tt := range tests {
+		debit, credit := journalTotals(tt.lines)
+		if debit != tt.debit || credit != tt.credit {
+			t.Errorf("%s: totals = %d/%d, want %d/%d", tt.name, debit, credit, tt.debit, tt.credit)
+		}
+	}
+}
+
+func TestParseJournalLine(t *testing.T) {
+	got, err := parseJournalLine("debit=6010,credit=1209,amount=2 500,debitVatCode=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := journalLineSpec{Debit: "6010", Credit: "1209", Amount: 250000, DebitVatCode: "1"}
+	if got != want {
+		t.Errorf("parseJournalLine = %+v, want %+v", got, want)
+	}
+
+	if _, err := parseJournalLine("debit=6010,account=1209"); err == nil {
+		t.Error("unknown key accepted")
+	}
+
+	account, cents, err := parseAccountAmount("2960 = 12 000,50")
+	if err != nil || account != "2960" || cents != 1200050 {
+		t.Errorf("parseAccountAmount = %q, %d, %v", account, cents, err)
+	}
+	if _, _, err := parseAccountAmount("=100"); err == nil {
+		t.Error("parseAccountAmount accepted a missing account")
+	}
+}
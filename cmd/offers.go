@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	offersLimit    int
+	offersPageSize int
+)
+
+var offerDraftKind = draftKind{
+	name:      "offer",
+	command:   "offers",
+	draftType: "offer",
+	drafts:    api.EndpointOfferDrafts,
+	draft:     api.EndpointOfferDraft,
+	finalize:  api.EndpointOfferDraftCreateOffer,
+	idKey:     "offerId",
+}
+
+var offersCmd = &cobra.Command{
+	Use:   "offers",
+	Short: "Manage offers",
+	Long: `List, create and convert offers (quotes).
+
+Offers take the same flags and file format as 'fiken invoices create'.
+An accepted offer is turned into an invoice draft with 'fiken offers convert'.`,
+}
+
+var offersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List offers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointOffers, slug)
+
+		offers, err := fetchPages[api.Offer](client, endpoint, nil, offersPageSize, offersLimit)
+		if err != nil {
+			return fmt.Errorf("fetching offers: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(offers)
+		}
+
+		if len(offers) == 0 {
+			output.PrintInfo("No offers found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "DATE", "CUSTOMER ID", "NET", "GROSS", "CURRENCY", "ARCHIVED")
+		for _, o := range offers {
+			table.AddRow(
+				fmt.Sprintf("%d", o.OfferId),
+				fmt.Sprintf("%d", o.OfferNumber),
+				o.Date,
+				fmt.Sprintf("%d", o.ContactId),
+				output.FormatAmount(o.Net),
+				output.FormatAmount(o.Gross),
+				o.Currency,
+				yesNo(o.Archived),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d offers\n", len(offers))
+		return nil
+	},
+}
+
+var offersGetCmd = &cobra.Command{
+	Use:   "get <offerId>",
+	Short: "Show an offer",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		offerID, err := parseID(args[0], "offer")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var offer api.Offer
+		_, err = client.Get(fmt.Sprintf(api.EndpointOffer, slug, offerID), &offer)
+		if err != nil {
+			return fmt.Errorf("fetching offer: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(offer)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", offer.OfferId))
+		d.Add("Number", fmt.Sprintf("%d", offer.OfferNumber))
+		d.Add("Date", offer.Date)
+		d.Add("Customer ID", fmt.Sprintf("%d", offer.ContactId))
+		d.Add("Our reference", offer.OurReference)
+		d.Add("Your reference", offer.YourReference)
+		d.Add("Order reference", offer.OrderReference)
+		d.Add("Currency", offer.Currency)
+		d.Add("Comment", offer.Comment)
+		d.Add("Archived", yesNo(offer.Archived))
+		d.Print()
+
+		fmt.Println()
+		printInvoiceLines(offer.Lines)
+
+		fmt.Println()
+		totals := output.NewDetails()
+		totals.Add("Net", output.FormatAmount(offer.Net))
+		totals.Add("VAT", output.FormatAmount(offer.Vat))
+		totals.Add("Gross", output.FormatAmount(offer.Gross))
+		totals.Print()
+		return nil
+	},
+}
+
+var offersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an offer",
+	Long: `Create an offer from flags, a JSON/YAML file, or both. The offer is
+staged as a draft and finalized in one step; use 'fiken offers drafts'
+to review it before finalizing.`,
+	Example: `  fiken offers create --customer "Acme AS" --line "desc=Website redesign,qty=40,price=1100"
+  fiken offers create --file offer.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, err := readInvoiceSpec(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		offerID, err := createFromDraft(client, slug, offerDraftKind, spec)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"offerId": offerID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Offer created (ID %d)", offerID))
+		return nil
+	},
+}
+
+var offersConvertCmd = &cobra.Command{
+	Use:   "convert <offerId>",
+	Short: "Create an invoice draft from an offer",
+	Long: `Create an invoice draft with the customer, references and lines of an
+offer. Invoice flags such as --issue-date, --due-days or --bank-account
+override the values taken from the offer, and --line adds extra lines.
+
+Review the draft and turn it into an invoice with
+'fiken invoices drafts finalize'.`,
+	Example: `  fiken offers convert 123
+  fiken offers convert 123 --due-days 30 --your-ref "PO 4411"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("file") {
+			return fmt.Errorf("--file cannot be used with convert")
+		}
+
+		offerID, err := parseID(args[0], "offer")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var offer api.Offer
+		_, err = client.Get(fmt.Sprintf(api.EndpointOffer, slug, offerID), &offer)
+		if err != nil {
+			return fmt.Errorf("fetching offer: %w", err)
+		}
+
+		spec := offerToSpec(offer)
+		if err := applyInvoiceCreateFlags(cmd, &spec); err != nil {
+			return err
+		}
+
+		draftID, err := createDraft(client, slug, invoiceDraftKind, spec)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"draftId": draftID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Invoice draft created from offer %d (ID %d)", offer.OfferNumber, draftID))
+		output.PrintInfo(fmt.Sprintf("Finalize it with 'fiken invoices drafts finalize %d'.", draftID))
+		return nil
+	},
+}
+
+// offerToSpec converts an offer into an invoiceSpec for an invoice draft.
+// The issue and due dates are left for buildInvoiceRequest to fill in.
+func offerToSpec(o api.Offer) invoiceSpec {
+	spec := invoiceSpec{
+		Customer:        idOrName(strconv.FormatInt(o.ContactId, 10)),
+		ContactPersonId: o.ContactPersonId,
+		Currency:        o.Currency,
+		InvoiceText:     o.Comment,
+		OurReference:    o.OurReference,
+		YourReference:   o.YourReference,
+		OrderReference:  o.OrderReference,
+		Lines:           invoiceLinesToSpec(o.Lines),
+	}
+	if o.ProjectId != 0 {
+		spec.Project = idOrName(strconv.FormatInt(o.ProjectId, 10))
+	}
+	return spec
+}
+
+// invoiceLinesToSpec converts document lines into invoice line specs.
+func invoiceLinesToSpec(lines []api.InvoiceLine) []invoiceLineSpec {
+	specs := make([]invoiceLineSpec, 0, len(lines))
+	for _, l := range lines {
+		description := l.Description
+		if description == "" {
+			description = l.ProductName
+		}
+		specs = append(specs, invoiceLineSpec{
+			Product:       l.ProductId,
+			Description:   description,
+			Comment:       l.Comment,
+			Quantity:      l.Quantity,
+			UnitPrice:     amount(l.UnitPrice),
+			Discount:      l.Discount,
+			VatType:       l.VatType,
+			IncomeAccount: l.IncomeAccount,
+		})
+	}
+	return specs
+}
+
+func init() {
+	offersListCmd.Flags().IntVar(&offersLimit, "limit", 0, "Maximum number of offers to show (0 = all)")
+	offersListCmd.Flags().IntVar(&offersPageSize, "page-size", api.MaxPageSize, "Number of offers to fetch per request")
+
+	addInvoiceSpecFlags(offersCreateCmd)
+	addInvoiceSpecFlags(offersConvertCmd)
+	offersConvertCmd.Flags().MarkHidden("file")
+
+	offersCmd.AddCommand(offersListCmd)
+	offersCmd.AddCommand(offersGetCmd)
+	offersCmd.AddCommand(offersCreateCmd)
+	offersCmd.AddCommand(offersConvertCmd)
+	offersCmd.AddCommand(newDraftsCmd(offerDraftKind))
+	rootCmd.AddCommand(offersCmd)
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+// vatRates maps Fiken VAT types to their rate in basis points (2500 = 25%).
+// It is used to split gross amounts into net and VAT.
+var vatRates = map[string]int64{
+	"HIGH":                 2500,
+	"HIGH_DIRECT":          2500,
+	"HIGH_BASIS":           2500,
+	"MEDIUM":               1500,
+	"MEDIUM_DIRECT":        1500,
+	"MEDIUM_BASIS":         1500,
+	"LOW":                  1200,
+	"RAW_FISH":             1111,
+	"NONE":                 0,
+	"EXEMPT":               0,
+	"OUTSIDE":              0,
+	"EXEMPT_IMPORT_EXPORT": 0,
+	"EXEMPT_REVERSE":       0,
+}
+
+// orderLineSpec is a sale or purchase line in an input file or --line flag.
+// Give either net (and optionally vatAmount) or gross; the rest is
+// calculated from the VAT type. Amounts are in kroner. On sale lines,
+// Product is a product ID whose defaults fill in the fields left empty,
+// with the net amount calculated from Quantity.
+type orderLineSpec struct {
+	Product     int64   `json:"product"`
+	Quantity    float64 `json:"quantity"`
+	Description string  `json:"description"`
+	Account     string  `json:"account"`
+	Net         amount  `json:"net"`
+	VatAmount   amount  `json:"vatAmount"`
+	Gross       amount  `json:"gross"`
+	VatType     string  `json:"vatType"`
+}
+
+// parseOrderLine parses a --line flag value into an order line.
+// Keys: description (desc), account, net, vatAmount, gross, vatType (vat),
+// product, quantity (qty).
+func parseOrderLine(s string) (orderLineSpec, error) {
+	values, err := parseKeyValues(s)
+	if err != nil {
+		return orderLineSpec{}, err
+	}
+
+	var line orderLineSpec
+	for key, value := range values {
+		var target *amount
+		switch strings.ToLower(key) {
+		case "product":
+			line.Product, err = strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return line, fmt.Errorf("invalid product ID %q in line %q", value, s)
+			}
+		case "quantity", "qty":
+			line.Quantity, err = strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+			if err != nil {
+				return line, fmt.Errorf("invalid quantity %q in line %q", value, s)
+			}
+		case "description", "desc":
+			line.Description = value
+		case "account":
+			line.Account = value
+		case "vattype", "vat":
+			line.VatType = value
+		case "net":
+			target = &line.Net
+		case "vatamount":
+			target = &line.VatAmount
+		case "gross":
+			target = &line.Gross
+		default:
+			return line, fmt.Errorf("unknown key %q in line %q", key, s)
+		}
+		if target != nil {
+			cents, err := parseAmount(value)
+			if err != nil {
+				return line, err
+			}
+			*target = amount(cents)
+		}
+	}
+	return line, nil
+}
+
+// toOrderLine validates a line spec and fills in the net, VAT and gross
+// amounts. vatType defaults to HIGH.
+func toOrderLine(l orderLineSpec) (api.OrderLine, error) {
+	vatType := strings.ToUpper(l.VatType)
+	if vatType == "" {
+		vatType = "HIGH"
+	}
+	if l.Description == "" {
+		return api.OrderLine{}, fmt.Errorf("description is required")
+	}
+	if l.Account == "" {
+		return api.OrderLine{}, fmt.Errorf("account is required")
+	}
+	if l.Net == 0 && l.Gross == 0 {
+		return api.OrderLine{}, fmt.Errorf("net or gross amount is required")
+	}
+
+	net, vat := int64(l.Net), int64(l.VatAmount)
+	rate, known := vatRates[vatType]
+	switch {
+	case l.Net == 0:
+		if !known {
+			return api.OrderLine{}, fmt.Errorf("cannot split gross amount for VAT type %s; give net and vatAmount", vatType)
+		}
+		gross := int64(l.Gross)
+		net = roundDiv(gross*10000, 10000+rate)
+		vat = gross - net
+	case l.VatAmount == 0 && known:
+		vat = roundDiv(net*rate, 10000)
+	}
+
+	return api.OrderLine{
+		Description: l.Description,
+		Account:     l.Account,
+		NetAmount:   net,
+		VatAmount:   vat,
+		GrossAmount: net + vat,
+		VatType:     vatType,
+	}, nil
+}
+
+// roundDiv divides a by b, rounding half away from zero.
+func roundDiv(a, b int64) int64 {
+	if (a < 0) != (b < 0) {
+		return (a - b/2) / b
+	}
+	return (a + b/2) / b
+}
+
+// orderLineFromProduct fills in the fields of l that are not set from
+// product. Without a net or gross amount, the net amount is the product's
+// unit price times the quantity (default 1).
+func orderLineFromProduct(l orderLineSpec, product api.Product) orderLineSpec {
+	if l.Description == "" {
+		l.Description = product.Name
+	}
+	if l.Account == "" {
+		l.Account = product.IncomeAccount
+	}
+	if l.VatType == "" {
+		l.VatType = product.VatType
+	}
+	if l.Net == 0 && l.Gross == 0 {
+		quantity := l.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		l.Net = amount(math.Round(float64(product.UnitPrice) * quantity))
+	}
+	return l
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jakoblind/fiken-cli/api"
+)
+
+func TestToOrderLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      orderLineSpec
+		want    api.OrderLine
+		wantErr string
+	}{
+		{
+			name: "gross split HIGH",
+			in:   orderLineSpec{Description: "d", Account: "3000", Gross: 10000},
+			want: api.OrderLine{NetAmount: 8000, VatAmount: 2000, GrossAmount: 10000, VatType: "HIGH"},
+		},
+		{
+			name: "gross split exact",
+			in:   orderLineSpec{Description: "d", Account: "3000", Gross: 12345, VatType: "HIGH"},
+			want: api.OrderLine{NetAmount: 9876, VatAmount: 2469, GrossAmount: 12345, VatType: "HIGH"},
+		},
+		{
+			name: "gross split rounds net and keeps gross",
+			in:   orderLineSpec{Description: "d", Account: "7140", Gross: 100, VatType: "low"},
+			want: api.OrderLine{NetAmount: 89, VatAmount: 11, GrossAmount: 100, VatType: "LOW"},
+		},
+		{
+			name: "gross split of one øre",
+			in:   orderLineSpec{Description: "d", Account: "3000", Gross: 1},
+			want: api.OrderLine{NetAmount: 1, VatAmount: 0, GrossAmount: 1, VatType: "HIGH"},
+		},
+		{
+			name: "negative gross rounds away from zero",
+			in:   orderLineSpec{Description: "d", Account: "3000", Gross: -101},
+			want: api.OrderLine{NetAmount: -81, VatAmount: -20, GrossAmount: -101, VatType: "HIGH"},
+		},
+		{
+			name: "gross without VAT",
+			in:   orderLineSpec{Description: "d", Account: "3000", Gross: 5000, VatType: "NONE"},
+			want: api.OrderLine{NetAmount: 5000, VatAmount: 0, GrossAmount: 5000, VatType: "NONE"},
+		},
+		{
+			name: "net adds VAT",
+			in:   orderLineSpec{Description: "d", Account: "3000", Net: 10000},
+			want: api.OrderLine{NetAmount: 10000, VatAmount: 2500, GrossAmount: 12500, VatType: "HIGH"},
+		},
+		{
+			name: "net rounds VAT half up",
+			in:   orderLineSpec{Description: "d", Account: "3000", Net: 999, VatType: "MEDIUM"},
+			want: api.OrderLine{NetAmount: 999, VatAmount: 150, GrossAmount: 1149, VatType: "MEDIUM"},
+		},
+		{
+			name: "net rounds VAT down",
+			in:   orderLineSpec{Description: "d", Account: "3000", Net: 101, VatType: "RAW_FISH"},
+			want: api.OrderLine{NetAmount: 101, VatAmount: 11, GrossAmount: 112, VatType: "RAW_FISH"},
+		},
+		{
+			name: "negative net",
+			in:   orderLineSpec{Description: "d", Account: "3000", Net: -10000},
+			want: api.OrderLine{NetAmount: -10000, VatAmount: -2500, GrossAmount: -12500, VatType: "HIGH"},
+		},
+		{
+			name: "explicit VAT amount is kept",
+			in:   orderLineSpec{Description: "d", Account: "3000", Net: 10000, VatAmount: 1234},
+			want: api.OrderLine{NetAmount: 10000, VatAmount: 1234, GrossAmount: 11234, VatType: "HIGH"},
+		},
+		{
+			name: "unknown VAT type with net",
+			in:   orderLineSpec{Description: "d", Account: "3000", Net: 10000, VatAmount: 500, VatType: "special"},
+			want: api.OrderLine{NetAmount: 10000, VatAmount: 500, GrossAmount: 10500, VatType: "SPECIAL"},
+		},
+		{
+			name:    "unknown VAT type with gross",
+			in:      orderLineSpec{Description: "d", Account: "3000", Gross: 10000, VatType: "SPECIAL"},
+			wantErr: "cannot split gross amount",
+		},
+		{
+			name:    "no description",
+			in:      orderLineSpec{Account: "3000", Net: 100},
+			wantErr: "description is required",
+		},
+		{
+			name:    "no account",
+			in:      orderLineSpec{Description: "d", Net: 100},
+			wantErr: "account is required",
+		},
+		{
+			name:    "no amount",
+			in:      orderLineSpec{Description: "d", Account: "3000"},
+			wantErr: "net or gross amount is required",
+		},
+	}
+	for _, tt := range tests {
+		got, err := toOrderLine(tt.in)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error: %v", tt.name, err)
+			continue
+		}
+		tt.want.Description = tt.in.Description
+		tt.want.Account = tt.in.Account
+		if got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRoundDiv(t *testing.T) {
+	tests := []struct{ a, b, want int64 }{
+		{5, 2, 3},
+		{4, 2, 2},
+		{-5, 2, -3},
+		{5, -2, -3},
+		{1, 3, 0},
+		{2, 3, 1},
+		{-2, 3, -1},
+		{0, 7, 0},
+	}
+	for _, tt := range tests {
+		if got := roundDiv(tt.a, tt.b); got != tt.want {
+			t.Errorf("roundDiv(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestParseOrderLine(t *testing.T) {
+	got, err := parseOrderLine("desc=Taxi, airport,account=7140,gross=389,vat=LOW,qty=1,5")
+	if err != nil {
+		t.Fatalf("parseOrderLine error: %v", err)
+	}
+	want := orderLineSpec{Description: "Taxi, airport", Account: "7140", Gross: 38900, VatType: "LOW", Quantity: 1.5}
+	if got != want {
+		t.Errorf("parseOrderLine = %+v, want %+v", got, want)
+	}
+
+	for _, s := range []string{
+		"desc=x,colour=red",
+		"desc=x,net=abc",
+		"desc=x,qty=two",
+		"desc=x,product=p1",
+	} {
+		if _, err := parseOrderLine(s); err == nil {
+			t.Errorf("parseOrderLine(%q) succeeded, want error", s)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ordersLimit    int
+	ordersPageSize int
+)
+
+var orderDraftKind = draftKind{
+	name:      "order confirmation",
+	command:   "orders",
+	draftType: "order_confirmation",
+	drafts:    api.EndpointOrderConfirmationDrafts,
+	draft:     api.EndpointOrderConfirmationDraft,
+	finalize:  api.EndpointOrderConfirmationDraftCreate,
+	idKey:     "confirmationId",
+}
+
+var ordersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Manage order confirmations",
+	Long: `List, create and convert order confirmations.
+
+Order confirmations take the same flags and file format as
+'fiken invoices create'. A confirmed order is turned into an invoice
+draft with 'fiken orders convert'.`,
+}
+
+var ordersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List order confirmations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointOrderConfirmations, slug)
+
+		orders, err := fetchPages[api.OrderConfirmation](client, endpoint, nil, ordersPageSize, ordersLimit)
+		if err != nil {
+			return fmt.Errorf("fetching order confirmations: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(orders)
+		}
+
+		if len(orders) == 0 {
+			output.PrintInfo("No order confirmations found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "DATE", "CUSTOMER ID", "NET", "GROSS", "CURRENCY", "INVOICE ID")
+		for _, o := range orders {
+			invoiceID := ""
+			if o.CreatedInvoiceId != 0 {
+				invoiceID = fmt.Sprintf("%d", o.CreatedInvoiceId)
+			}
+			table.AddRow(
+				fmt.Sprintf("%d", o.ConfirmationId),
+				fmt.Sprintf("%d", o.ConfirmationNumber),
+				o.Date,
+				fmt.Sprintf("%d", o.ContactId),
+				output.FormatAmount(o.Net),
+				output.FormatAmount(o.Gross),
+				o.Currency,
+				invoiceID,
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d order confirmations\n", len(orders))
+		return nil
+	},
+}
+
+var ordersGetCmd = &cobra.Command{
+	Use:   "get <confirmationId>",
+	Short: "Show an order confirmation",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirmationID, err := parseID(args[0], "order confirmation")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var order api.OrderConfirmation
+		_, err = client.Get(fmt.Sprintf(api.EndpointOrderConfirmation, slug, confirmationID), &order)
+		if err != nil {
+			return fmt.Errorf("fetching order confirmation: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(order)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", order.ConfirmationId))
+		d.Add("Number", fmt.Sprintf("%d", order.ConfirmationNumber))
+		d.Add("Date", order.Date)
+		d.Add("Customer ID", fmt.Sprintf("%d", order.ContactId))
+		d.Add("Our reference", order.OurReference)
+		d.Add("Your reference", order.YourReference)
+		d.Add("Order reference", order.OrderReference)
+		d.Add("Currency", order.Currency)
+		d.Add("Comment", order.Comment)
+		if order.CreatedInvoiceId != 0 {
+			d.Add("Invoice ID", fmt.Sprintf("%d", order.CreatedInvoiceId))
+		}
+		d.Add("Archived", yesNo(order.Archived))
+		d.Print()
+
+		fmt.Println()
+		printInvoiceLines(order.Lines)
+
+		fmt.Println()
+		totals := output.NewDetails()
+		totals.Add("Net", output.FormatAmount(order.Net))
+		totals.Add("VAT", output.FormatAmount(order.Vat))
+		totals.Add("Gross", output.FormatAmount(order.Gross))
+		totals.Print()
+		return nil
+	},
+}
+
+var ordersCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an order confirmation",
+	Long: `Create an order confirmation from flags, a JSON/YAML file, or both.
+The order confirmation is staged as a draft and finalized in one step;
+use 'fiken orders drafts' to review it before finalizing.`,
+	Example: `  fiken orders create --customer "Acme AS" --product 123 --your-ref "PO 4411"
+  fiken orders create --file order.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, err := readInvoiceSpec(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		confirmationID, err := createFromDraft(client, slug, orderDraftKind, spec)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"confirmationId": confirmationID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Order confirmation created (ID %d)", confirmationID))
+		return nil
+	},
+}
+
+var ordersConvertCmd = &cobra.Command{
+	Use:   "convert <confirmationId>",
+	Short: "Create an invoice draft from an order confirmation",
+	Long: `Create an invoice draft from an order confirmation. Fiken links the
+draft to the order confirmation, which is then shown as invoiced.
+
+Review the draft and turn it into an invoice with
+'fiken invoices drafts finalize'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirmationID, err := parseID(args[0], "order confirmation")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointOrderConfirmationCreateInvoiceDraft, slug, confirmationID)
+		draftID, err := client.PostForID(endpoint, nil)
+		if err != nil {
+			return fmt.Errorf("creating invoice draft from order confirmation %d: %w", confirmationID, err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"draftId": draftID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Invoice draft created from order confirmation %d (ID %d)", confirmationID, draftID))
+		output.PrintInfo(fmt.Sprintf("Finalize it with 'fiken invoices drafts finalize %d'.", draftID))
+		return nil
+	},
+}
+
+func init() {
+	ordersListCmd.Flags().IntVar(&ordersLimit, "limit", 0, "Maximum number of order confirmations to show (0 = all)")
+	ordersListCmd.Flags().IntVar(&ordersPageSize, "page-size", api.MaxPageSize, "Number of order confirmations to fetch per request")
+
+	addInvoiceSpecFlags(ordersCreateCmd)
+
+	ordersCmd.AddCommand(ordersListCmd)
+	ordersCmd.AddCommand(ordersGetCmd)
+	ordersCmd.AddCommand(ordersCreateCmd)
+	ordersCmd.AddCommand(ordersConvertCmd)
+	ordersCmd.AddCommand(newDraftsCmd(orderDraftKind))
+	rootCmd.AddCommand(ordersCmd)
+}
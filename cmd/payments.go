@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+// outstandingFunc returns the unpaid amount and currency of a document.
+type outstandingFunc func(client *api.Client, slug string, id int64) (int64, string, error)
+
+// newPaymentsCmd builds the payments subcommand for a document type such as
+// "sale" or "purchase". endpoint is the payments endpoint format taking the
+// company slug and document ID; outstanding supplies the default amount.
+func newPaymentsCmd(docName, endpoint string, outstanding outstandingFunc) *cobra.Command {
+	var input struct {
+		date        string
+		amount      string
+		amountInNok string
+		account     string
+		currency    string
+		fee         string
+	}
+
+	paymentsCmd := &cobra.Command{
+		Use:   "payments",
+		Short: fmt.Sprintf("Manage payments on a %s", docName),
+	}
+
+	listCmd := &cobra.Command{
+		Use:   fmt.Sprintf("list <%sId>", docName),
+		Short: fmt.Sprintf("List payments on a %s", docName),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docID, err := parseID(args[0], docName)
+			if err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			payments, err := fetchPages[api.Payment](client, fmt.Sprintf(endpoint, slug, docID), nil, api.MaxPageSize, 0)
+			if err != nil {
+				return fmt.Errorf("fetching payments: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(payments)
+			}
+
+			if len(payments) == 0 {
+				output.PrintInfo("No payments found.")
+				return nil
+			}
+
+			table := output.NewTable("ID", "DATE", "ACCOUNT", "AMOUNT", "CURRENCY", "FEE")
+			var total int64
+			for _, p := range payments {
+				total += p.Amount
+				table.AddRow(
+					fmt.Sprintf("%d", p.PaymentId),
+					p.Date,
+					p.Account,
+					output.FormatAmount(p.Amount),
+					p.Currency,
+					output.FormatAmount(p.Fee),
+				)
+			}
+			table.Print()
+
+			fmt.Printf("\n%d payments, total %s\n", len(payments), output.FormatAmount(total))
+			return nil
+		},
+	}
+
+	addCmd := &cobra.Command{
+		Use:   fmt.Sprintf("add <%sId>", docName),
+		Short: fmt.Sprintf("Register a payment on a %s", docName),
+		Long: fmt.Sprintf(`Register a payment on a %s.
+
+The amount defaults to the outstanding amount, the date to today and the
+account to the company's only active bank account.`, docName),
+		Args: cobra.ExactArgs(1),
+		Example: fmt.Sprintf(`  fiken %[1]ss payments add 123
+  fiken %[1]ss payments add 123 --amount 1250,00 --date 2024-02-01 --account 1920:10001`, docName),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			docID, err := parseID(args[0], docName)
+			if err != nil {
+				return err
+			}
+
+			date := input.date
+			if date == "" {
+				date = time.Now().Format("2006-01-02")
+			}
+			if err := validateDate(date); err != nil {
+				return err
+			}
+
+			client, err := getClient()
+			if err != nil {
+				return err
+			}
+
+			slug, err := resolveCompany(client)
+			if err != nil {
+				return err
+			}
+
+			req := api.PaymentRequest{
+				Date:     date,
+				Currency: input.currency,
+			}
+
+			if input.amount != "" {
+				req.Amount, err = parseAmount(input.amount)
+				if err != nil {
+					return err
+				}
+			} else {
+				var currency string
+				req.Amount, currency, err = outstanding(client, slug, docID)
+				if err != nil {
+					return err
+				}
+				if req.Amount <= 0 {
+					return fmt.Errorf("%s %d has nothing outstanding; give --amount explicitly", docName, docID)
+				}
+				if req.Currency == "" {
+					req.Currency = currency
+				}
+			}
+			if input.amountInNok != "" {
+				req.AmountInNok, err = parseAmount(input.amountInNok)
+				if err != nil {
+					return err
+				}
+			}
+			if input.fee != "" {
+				req.Fee, err = parseAmount(input.fee)
+				if err != nil {
+					return err
+				}
+			}
+
+			req.Account, err = resolvePaymentAccount(client, slug, input.account)
+			if err != nil {
+				return err
+			}
+
+			paymentID, err := client.PostForID(fmt.Sprintf(endpoint, slug, docID), req)
+			if err != nil {
+				return fmt.Errorf("registering payment: %w", err)
+			}
+
+			if jsonOutput {
+				return output.PrintJSON(map[string]int64{"paymentId": paymentID})
+			}
+			output.PrintSuccess(fmt.Sprintf("Payment of %s registered on %s %d (ID %d)",
+				output.FormatAmount(req.Amount), docName, docID, paymentID))
+			return nil
+		},
+	}
+
+	f := addCmd.Flags()
+	f.StringVar(&input.date, "date", "", "Payment date (YYYY-MM-DD, default today)")
+	f.StringVar(&input.amount, "amount", "", "Amount in kroner (default the outstanding amount)")
+	f.StringVar(&input.amountInNok, "amount-nok", "", "Amount in NOK for payments in foreign currency")
+	f.StringVar(&input.account, "account", "", "Account the payment was made from/to (e.g. 1920:10001)")
+	f.StringVar(&input.currency, "currency", "", "Currency (default the document currency)")
+	f.StringVar(&input.fee, "fee", "", "Bank fee in kroner")
+
+	paymentsCmd.AddCommand(listCmd)
+	paymentsCmd.AddCommand(addCmd)
+	return paymentsCmd
+}
@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	productsName     string
+	productsNumber   string
+	productsInactive bool
+	productsLimit    int
+	productsPageSize int
+)
+
+// productInput holds the flags shared by products create and update.
+var productInput struct {
+	name          string
+	price         string
+	vatType       string
+	incomeAccount string
+	productNumber string
+	stock         float64
+	note          string
+	inactive      bool
+}
+
+var productsCmd = &cobra.Command{
+	Use:   "products",
+	Short: "Manage products",
+	Long: `List and manage the product register.
+
+Products can be used on invoice and sale lines with --product <id>, which
+fills in the description, price, VAT type and income account.`,
+}
+
+var productsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List products",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if productsName != "" {
+			params.Set("name", productsName)
+		}
+		if productsNumber != "" {
+			params.Set("productNumber", productsNumber)
+		}
+		if cmd.Flags().Changed("inactive") {
+			params.Set("active", strconv.FormatBool(!productsInactive))
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointProducts, slug)
+
+		products, err := fetchPages[api.Product](client, endpoint, params, productsPageSize, productsLimit)
+		if err != nil {
+			return fmt.Errorf("fetching products: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(products)
+		}
+
+		if len(products) == 0 {
+			output.PrintInfo("No products found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "NAME", "PRICE", "VAT", "ACCOUNT", "STOCK", "ACTIVE")
+		for _, p := range products {
+			table.AddRow(
+				fmt.Sprintf("%d", p.ProductId),
+				p.ProductNumber,
+				p.Name,
+				output.FormatAmount(p.UnitPrice),
+				p.VatType,
+				p.IncomeAccount,
+				formatQuantity(p.Stock),
+				yesNo(p.Active),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d products\n", len(products))
+		return nil
+	},
+}
+
+var productsGetCmd = &cobra.Command{
+	Use:   "get <productId>",
+	Short: "Show a product",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productID, err := parseID(args[0], "product")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		product, err := fetchProduct(client, slug, productID)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(product)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", product.ProductId))
+		d.Add("Number", product.ProductNumber)
+		d.Add("Name", product.Name)
+		d.Add("Unit price", output.FormatAmount(product.UnitPrice))
+		d.Add("VAT type", product.VatType)
+		d.Add("Income account", product.IncomeAccount)
+		d.Add("Stock", formatQuantity(product.Stock))
+		d.Add("Active", yesNo(product.Active))
+		d.Add("Note", product.Note)
+		d.Add("Created", product.CreatedDate)
+		d.Add("Last modified", product.LastModifiedDate)
+		d.Print()
+		return nil
+	},
+}
+
+var productsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a product",
+	Long: `Create a product. The unit price is the net price in kroner.
+The VAT type defaults to HIGH and the income account to 3000.`,
+	Example: `  fiken products create --name "Consulting hour" --price 1200 --number KONS-1
+  fiken products create --name "Book" --price 300 --vat LOW --account 3020`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if productInput.name == "" {
+			return fmt.Errorf("--name is required")
+		}
+
+		req := api.ProductRequest{
+			VatType:       "HIGH",
+			IncomeAccount: "3000",
+			Active:        true,
+		}
+		if err := applyProductFlags(cmd, &req); err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if err := validateAccounts(client, slug, []string{req.IncomeAccount}); err != nil {
+			return err
+		}
+
+		productID, err := client.PostForID(fmt.Sprintf(api.EndpointProducts, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating product: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"productId": productID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Product '%s' created (ID %d)", req.Name, productID))
+		return nil
+	},
+}
+
+var productsUpdateCmd = &cobra.Command{
+	Use:   "update <productId>",
+	Short: "Update a product",
+	Long:  "Update a product. Only the fields given as flags are changed.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productID, err := parseID(args[0], "product")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		// Fiken replaces the whole product on update, so start from the current one.
+		product, err := fetchProduct(client, slug, productID)
+		if err != nil {
+			return err
+		}
+
+		req := api.ProductRequest{
+			Name:          product.Name,
+			UnitPrice:     product.UnitPrice,
+			IncomeAccount: product.IncomeAccount,
+			VatType:       product.VatType,
+			Active:        product.Active,
+			ProductNumber: product.ProductNumber,
+			Stock:         product.Stock,
+			Note:          product.Note,
+		}
+		if err := applyProductFlags(cmd, &req); err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("account") {
+			if err := validateAccounts(client, slug, []string{req.IncomeAccount}); err != nil {
+				return err
+			}
+		}
+
+		if err := client.Put(fmt.Sprintf(api.EndpointProduct, slug, productID), req, nil); err != nil {
+			return fmt.Errorf("updating product: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Product %d updated", productID))
+		return nil
+	},
+}
+
+var productsDeleteCmd = &cobra.Command{
+	Use:   "delete <productId>",
+	Short: "Delete a product",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		productID, err := parseID(args[0], "product")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Delete product %d?", productID)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		if err := client.Delete(fmt.Sprintf(api.EndpointProduct, slug, productID)); err != nil {
+			return fmt.Errorf("deleting product: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Product %d deleted", productID))
+		return nil
+	},
+}
+
+// fetchProduct fetches a single product by ID.
+func fetchProduct(client *api.Client, slug string, productID int64) (api.Product, error) {
+	var product api.Product
+	_, err := client.Get(fmt.Sprintf(api.EndpointProduct, slug, productID), &product)
+	if err != nil {
+		return api.Product{}, fmt.Errorf("fetching product %d: %w", productID, err)
+	}
+	return product, nil
+}
+
+// invoiceLineFromProduct fills in the fields of l that are not set from
+// product.
+func invoiceLineFromProduct(l invoiceLineSpec, product api.Product) invoiceLineSpec {
+	if l.Description == "" {
+		l.Description = product.Name
+	}
+	if l.UnitPrice == 0 {
+		l.UnitPrice = amount(product.UnitPrice)
+	}
+	if l.VatType == "" {
+		l.VatType = product.VatType
+	}
+	if l.IncomeAccount == "" {
+		l.IncomeAccount = product.IncomeAccount
+	}
+	return l
+}
+
+// applyProductFlags copies the product flags that were set on cmd into req.
+func applyProductFlags(cmd *cobra.Command, req *api.ProductRequest) error {
+	flags := cmd.Flags()
+	if flags.Changed("name") {
+		req.Name = productInput.name
+	}
+	if flags.Changed("price") {
+		price, err := parseAmount(productInput.price)
+		if err != nil {
+			return err
+		}
+		req.UnitPrice = price
+	}
+	if flags.Changed("vat") {
+		req.VatType = strings.ToUpper(productInput.vatType)
+	}
+	if flags.Changed("account") {
+		req.IncomeAccount = productInput.incomeAccount
+	}
+	if flags.Changed("number") {
+		req.ProductNumber = productInput.productNumber
+	}
+	if flags.Changed("stock") {
+		req.Stock = productInput.stock
+	}
+	if flags.Changed("note") {
+		req.Note = productInput.note
+	}
+	if flags.Changed("inactive") {
+		req.Active = !productInput.inactive
+	}
+	return nil
+}
+
+// addProductFlags registers the flags shared by products create and update.
+func addProductFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&productInput.name, "name", "", "Product name")
+	cmd.Flags().StringVar(&productInput.price, "price", "", "Net unit price in kroner")
+	cmd.Flags().StringVar(&productInput.vatType, "vat", "", "VAT type (HIGH, MEDIUM, LOW, NONE, EXEMPT, OUTSIDE, ...)")
+	cmd.Flags().StringVar(&productInput.incomeAccount, "account", "", "Income account (e.g. 3000)")
+	cmd.Flags().StringVar(&productInput.productNumber, "number", "", "Product number")
+	cmd.Flags().Float64Var(&productInput.stock, "stock", 0, "Number of items in stock")
+	cmd.Flags().StringVar(&productInput.note, "note", "", "Internal note")
+	cmd.Flags().BoolVar(&productInput.inactive, "inactive", false, "Mark as inactive")
+}
+
+// formatQuantity formats a quantity without trailing zeros.
+func formatQuantity(q float64) string {
+	return strconv.FormatFloat(q, 'f', -1, 64)
+}
+
+func init() {
+	productsListCmd.Flags().StringVar(&productsName, "name", "", "Filter by name")
+	productsListCmd.Flags().StringVar(&productsNumber, "number", "", "Filter by product number")
+	productsListCmd.Flags().BoolVar(&productsInactive, "inactive", false, "Only inactive products (--inactive=false for active)")
+	productsListCmd.Flags().IntVar(&productsLimit, "limit", 0, "Maximum number of products to show (0 = all)")
+	productsListCmd.Flags().IntVar(&productsPageSize, "page-size", api.MaxPageSize, "Number of products to fetch per request")
+
+	addProductFlags(productsCreateCmd)
+	addProductFlags(productsUpdateCmd)
+
+	productsCmd.AddCommand(productsListCmd)
+	productsCmd.AddCommand(productsGetCmd)
+	productsCmd.AddCommand(productsCreateCmd)
+	productsCmd.AddCommand(productsUpdateCmd)
+	productsCmd.AddCommand(productsDeleteCmd)
+	rootCmd.AddCommand(productsCmd)
+}
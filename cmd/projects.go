@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectsCompleted bool
+	projectsLimit     int
+	projectsPageSize  int
+)
+
+// projectInput holds the flags shared by projects create and update.
+var projectInput struct {
+	number      string
+	name        string
+	description string
+	startDate   string
+	endDate     string
+	contact     string
+	completed   bool
+}
+
+var projectsCmd = &cobra.Command{
+	Use:   "projects",
+	Short: "Manage projects",
+	Long: `List and manage projects for tracking income and costs.
+
+Purchases, sales, invoices and journal entries can be tagged with
+--project when created, and their list commands can filter by project.`,
+}
+
+var projectsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List projects",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if cmd.Flags().Changed("completed") {
+			params.Set("completed", strconv.FormatBool(projectsCompleted))
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointProjects, slug)
+
+		projects, err := fetchPages[api.Project](client, endpoint, params, projectsPageSize, projectsLimit)
+		if err != nil {
+			return fmt.Errorf("fetching projects: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(projects)
+		}
+
+		if len(projects) == 0 {
+			output.PrintInfo("No projects found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "NAME", "CONTACT", "START", "END", "COMPLETED")
+		for _, p := range projects {
+			contact := ""
+			if p.Contact != nil {
+				contact = p.Contact.Name
+			}
+			table.AddRow(
+				fmt.Sprintf("%d", p.ProjectId),
+				p.Number,
+				p.Name,
+				contact,
+				p.StartDate,
+				p.EndDate,
+				yesNo(p.Completed),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d projects\n", len(projects))
+		return nil
+	},
+}
+
+var projectsGetCmd = &cobra.Command{
+	Use:   "get <project>",
+	Short: "Show a project",
+	Long:  "Show a project, given by ID, number or name.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		project, err := resolveProject(client, slug, args[0])
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(project)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", project.ProjectId))
+		d.Add("Number", project.Number)
+		d.Add("Name", project.Name)
+		d.Add("Description", project.Description)
+		if project.Contact != nil {
+			d.Add("Contact", fmt.Sprintf("%s (%d)", project.Contact.Name, project.Contact.ContactId))
+		}
+		d.Add("Start date", project.StartDate)
+		d.Add("End date", project.EndDate)
+		d.Add("Completed", yesNo(project.Completed))
+		d.Print()
+		return nil
+	},
+}
+
+var projectsCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a project",
+	Example: `  fiken projects create --number P-101 --name "Acme website" --contact "Acme AS"
+  fiken projects create --number P-102 --name "Internal tools" --start-date 2024-01-01`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if projectInput.number == "" || projectInput.name == "" {
+			return fmt.Errorf("--number and --name are required")
+		}
+
+		req := api.ProjectRequest{
+			Number:      projectInput.number,
+			Name:        projectInput.name,
+			Description: projectInput.description,
+			StartDate:   projectInput.startDate,
+			EndDate:     projectInput.endDate,
+			Completed:   projectInput.completed,
+		}
+		if req.StartDate == "" {
+			req.StartDate = time.Now().Format("2006-01-02")
+		}
+		for _, date := range []string{req.StartDate, req.EndDate} {
+			if date == "" {
+				continue
+			}
+			if err := validateDate(date); err != nil {
+				return err
+			}
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if projectInput.contact != "" {
+			contact, err := resolveContact(client, slug, projectInput.contact)
+			if err != nil {
+				return err
+			}
+			req.ContactId = contact.ContactId
+		}
+
+		projectID, err := client.PostForID(fmt.Sprintf(api.EndpointProjects, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating project: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"projectId": projectID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Project '%s' created (ID %d)", req.Name, projectID))
+		return nil
+	},
+}
+
+var projectsUpdateCmd = &cobra.Command{
+	Use:   "update <project>",
+	Short: "Update a project",
+	Long: `Update a project, given by ID, number or name. Only the fields given as
+flags are changed; an empty --description or --end-date clears it.`,
+	Example: `  fiken projects update P-101 --end-date 2024-06-30 --completed
+  fiken projects update 12345 --name "Acme web shop"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req, err := projectUpdateRequest(cmd)
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		project, err := resolveProject(client, slug, args[0])
+		if err != nil {
+			return err
+		}
+
+		if cmd.Flags().Changed("contact") {
+			contact, err := resolveContact(client, slug, projectInput.contact)
+			if err != nil {
+				return err
+			}
+			req.ContactId = &contact.ContactId
+		}
+
+		// Fiken updates projects with PATCH, so only the changed fields are sent.
+		if err := client.Patch(fmt.Sprintf(api.EndpointProject, slug, project.ProjectId), req, nil); err != nil {
+			return fmt.Errorf("updating project: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Project %d updated", project.ProjectId))
+		return nil
+	},
+}
+
+// projectUpdateRequest builds a project update from the flags set on cmd,
+// except --contact, which needs a lookup.
+func projectUpdateRequest(cmd *cobra.Command) (api.ProjectUpdateRequest, error) {
+	flags := cmd.Flags()
+	req := api.ProjectUpdateRequest{}
+	if flags.Changed("number") {
+		if projectInput.number == "" {
+			return req, fmt.Errorf("the project number cannot be empty")
+		}
+		req.Number = &projectInput.number
+	}
+	if flags.Changed("name") {
+		if projectInput.name == "" {
+			return req, fmt.Errorf("the project name cannot be empty")
+		}
+		req.Name = &projectInput.name
+	}
+	if flags.Changed("description") {
+		req.Description = &projectInput.description
+	}
+	if flags.Changed("start-date") {
+		if err := validateDate(projectInput.startDate); err != nil {
+			return req, err
+		}
+		req.StartDate = &projectInput.startDate
+	}
+	if flags.Changed("end-date") {
+		if projectInput.endDate != "" {
+			if err := validateDate(projectInput.endDate); err != nil {
+				return req, err
+			}
+		}
+		req.EndDate = &projectInput.endDate
+	}
+	if flags.Changed("completed") {
+		req.Completed = &projectInput.completed
+	}
+	return req, nil
+}
+
+var projectsDeleteCmd = &cobra.Command{
+	Use:   "delete <project>",
+	Short: "Delete a project",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		project, err := resolveProject(client, slug, args[0])
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Delete project %s %s (%d)?", project.Number, project.Name, project.ProjectId)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		if err := client.Delete(fmt.Sprintf(api.EndpointProject, slug, project.ProjectId)); err != nil {
+			return fmt.Errorf("deleting project: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Project %d deleted", project.ProjectId))
+		return nil
+	},
+}
+
+// resolveProject looks up a project by ID, number or name. Names are
+// matched ignoring case and must match exactly one project.
+func resolveProject(client *api.Client, slug, ref string) (api.Project, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return api.Project{}, fmt.Errorf("no project given")
+	}
+
+	projects, err := fetchPages[api.Project](client, fmt.Sprintf(api.EndpointProjects, slug), nil, api.MaxPageSize, 0)
+	if err != nil {
+		return api.Project{}, fmt.Errorf("fetching projects: %w", err)
+	}
+
+	for _, p := range projects {
+		if strconv.FormatInt(p.ProjectId, 10) == ref {
+			return p, nil
+		}
+	}
+	for _, p := range projects {
+		if p.Number == ref {
+			return p, nil
+		}
+	}
+
+	var matches []api.Project
+	for _, p := range projects {
+		if strings.EqualFold(p.Name, ref) {
+			matches = append(matches, p)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return api.Project{}, fmt.Errorf("no project found matching %q. See 'fiken projects list'", ref)
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, p := range matches {
+		names[i] = fmt.Sprintf("  %s %s (%d)", p.Number, p.Name, p.ProjectId)
+	}
+	return api.Project{}, fmt.Errorf("multiple projects match %q. Use the project ID or number instead:\n%s", ref, strings.Join(names, "\n"))
+}
+
+// resolveProjectID resolves an optional project reference to its ID.
+// An empty ref gives 0.
+func resolveProjectID(client *api.Client, slug, ref string) (int64, error) {
+	if ref == "" {
+		return 0, nil
+	}
+	project, err := resolveProject(client, slug, ref)
+	if err != nil {
+		return 0, err
+	}
+	return project.ProjectId, nil
+}
+
+// projectIDOf returns the ID of p, or 0 if p is nil.
+func projectIDOf(p *api.Project) int64 {
+	if p == nil {
+		return 0
+	}
+	return p.ProjectId
+}
+
+// projectLabel formats a project as "number name", or "" if p is nil.
+func projectLabel(p *api.Project) string {
+	if p == nil {
+		return ""
+	}
+	return strings.TrimSpace(p.Number + " " + p.Name)
+}
+
+// addProjectFlags registers the flags shared by projects create and update.
+func addProjectFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&projectInput.number, "number", "", "Project number")
+	cmd.Flags().StringVar(&projectInput.name, "name", "", "Project name")
+	cmd.Flags().StringVar(&projectInput.description, "description", "", "Description")
+	cmd.Flags().StringVar(&projectInput.startDate, "start-date", "", "Start date (YYYY-MM-DD, default today)")
+	cmd.Flags().StringVar(&projectInput.endDate, "end-date", "", "End date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&projectInput.contact, "contact", "", "Customer contact ID or name")
+	cmd.Flags().BoolVar(&projectInput.completed, "completed", false, "Mark as completed")
+}
+
+func init() {
+	projectsListCmd.Flags().BoolVar(&projectsCompleted, "completed", false, "Only completed projects (--completed=false for ongoing)")
+	projectsListCmd.Flags().IntVar(&projectsLimit, "limit", 0, "Maximum number of projects to show (0 = all)")
+	projectsListCmd.Flags().IntVar(&projectsPageSize, "page-size", api.MaxPageSize, "Number of projects to fetch per request")
+
+	addProjectFlags(projectsCreateCmd)
+	addProjectFlags(projectsUpdateCmd)
+
+	projectsCmd.AddCommand(projectsListCmd)
+	projectsCmd.AddCommand(projectsGetCmd)
+	projectsCmd.AddCommand(projectsCreateCmd)
+	projectsCmd.AddCommand(projectsUpdateCmd)
+	projectsCmd.AddCommand(projectsDeleteCmd)
+	rootCmd.AddCommand(projectsCmd)
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestProjectUpdateRequest(t *testing.T) {
+	saved := projectInput
+	t.Cleanup(func() { projectInput = saved })
+
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		want    string
+		wantErr string
+	}{
+		{name: "nothing", want: `{}`},
+		{name: "rename", flags: map[string]string{"name": "Web shop"}, want: `{"name":"Web shop"}`},
+		{name: "clear description", flags: map[string]string{"description": ""}, want: `{"description":""}`},
+		{name: "clear end date", flags: map[string]string{"end-date": ""}, want: `{"endDate":""}`},
+		{name: "reopen", flags: map[string]string{"completed": "false"}, want: `{"completed":false}`},
+		{
+			name:  "several",
+			flags: map[string]string{"end-date": "2024-06-30", "completed": "true", "start-date": "2024-01-01"},
+			want:  `{"startDate":"2024-01-01","endDate":"2024-06-30","completed":true}`,
+		},
+		{name: "bad end date", flags: map[string]string{"end-date": "30.06.2024"}, wantErr: "invalid date"},
+		{name: "empty start date", flags: map[string]string{"start-date": ""}, wantErr: "invalid date"},
+		{name: "empty name", flags: map[string]string{"name": ""}, wantErr: "name cannot be empty"},
+		{name: "empty number", flags: map[string]string{"number": ""}, wantErr: "number cannot be empty"},
+	}
+	for _, tt := range tests {
+		cmd := &cobra.Command{}
+		addProjectFlags(cmd)
+		for name, value := range tt.flags {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		req, err := projectUpdateRequest(cmd)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error: %v", tt.name, err)
+			continue
+		}
+		data, _ := json.Marshal(req)
+		if string(data) != tt.want {
+			t.Errorf("%s: request = %s, want %s", tt.name, data, tt.want)
+		}
+	}
+}
@@ -3,22 +3,101 @@ package cmd
 import (
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/jakoblind/fiken-cli/api"
 	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+// purchasesDefaultPages is how many pages purchases list fetches when
+// neither --all nor --limit is given.
+const purchasesDefaultPages = 4
+
+var (
+	purchasesFrom        string
+	purchasesTo          string
+	purchasesPaid        bool
+	purchasesUnpaid      bool
+	purchasesSupplier    string
+	purchasesProject     string
+	purchasesAll         bool
+	purchasesLimit       int
+	purchasesPageSize    int
+	purchasesDescription string
+)
+
+// purchaseCreateInput holds the flags for purchases create.
+var purchaseCreateInput struct {
+	file           string
+	kind           string
+	date           string
+	dueDate        string
+	identifier     string
+	supplier       string
+	currency       string
+	paymentAccount string
+	paymentDate    string
+	kid            string
+	attach         string
+	project        string
+	lines          []string
+}
+
+// purchaseKinds are the purchase kinds accepted by Fiken.
+var purchaseKinds = []string{"cash_purchase", "supplier"}
+
+// purchaseVatTypes are the VAT types Fiken accepts on purchase lines.
+var purchaseVatTypes = []string{
+	"HIGH", "MEDIUM", "LOW", "RAW_FISH", "NONE",
+	"HIGH_DIRECT", "HIGH_BASIS", "MEDIUM_DIRECT", "MEDIUM_BASIS", "NONE_IMPORT_BASIS",
+	"HIGH_FOREIGN_SERVICE_DEDUCTIBLE", "HIGH_FOREIGN_SERVICE_NONDEDUCTIBLE",
+	"LOW_FOREIGN_SERVICE_DEDUCTIBLE", "LOW_FOREIGN_SERVICE_NONDEDUCTIBLE",
+	"HIGH_PURCHASE_OF_EMISSIONSTRADING_OR_GOLD_DEDUCTIBLE",
+	"HIGH_PURCHASE_OF_EMISSIONSTRADING_OR_GOLD_NONDEDUCTIBLE",
+}
+
+// purchaseSpec is the file format accepted by purchases create --file.
+type purchaseSpec struct {
+	Kind           string          `json:"kind"`
+	Date           string          `json:"date"`
+	DueDate        string          `json:"dueDate"`
+	Identifier     string          `json:"identifier"`
+	Supplier       idOrName        `json:"supplier"`
+	Currency       string          `json:"currency"`
+	PaymentAccount string          `json:"paymentAccount"`
+	PaymentDate    string          `json:"paymentDate"`
+	Kid            string          `json:"kid"`
+	Attachment     string          `json:"attachment"`
+	Project        idOrName        `json:"project"`
+	Lines          []orderLineSpec `json:"lines"`
+}
+
 var purchasesCmd = &cobra.Command{
 	Use:   "purchases",
 	Short: "Manage purchases",
-	Long:  "List and manage purchases/expenses.",
+	Long:  "List, inspect, create and delete purchases/expenses.",
 }
 
 var purchasesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List purchases",
+	Long: `List purchases, newest pages first as returned by Fiken.
+
+Without --all or --limit only the first 4 pages are fetched. The --paid,
+--unpaid, --supplier and --project filters are applied locally to the
+fetched purchases.`,
+	Example: `  fiken purchases list --from 2023-01-01 --to 2023-12-31 --supplier "Acme AS"
+  fiken purchases list --unpaid --all`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if purchasesPaid && purchasesUnpaid {
+			return fmt.Errorf("--paid and --unpaid cannot be combined")
+		}
+
 		client, err := getClient()
 		if err != nil {
 			return err
@@ -30,29 +109,65 @@ var purchasesListCmd = &cobra.Command{
 		}
 
 		params := url.Values{}
-		params.Set("pageSize", "25")
-
-		endpoint := fmt.Sprintf(api.EndpointPurchases, slug)
+		if purchasesFrom != "" {
+			if err := validateDate(purchasesFrom); err != nil {
+				return err
+			}
+			params.Set("dateGe", purchasesFrom)
+		}
+		if purchasesTo != "" {
+			if err := validateDate(purchasesTo); err != nil {
+				return err
+			}
+			params.Set("dateLe", purchasesTo)
+		}
 
-		var purchases []api.Purchase
-		page := 0
-		for {
-			params.Set("page", fmt.Sprintf("%d", page))
-			var pagePurchases []api.Purchase
-			pagination, err := client.GetWithParams(endpoint, params, &pagePurchases)
+		var supplierID int64
+		if purchasesSupplier != "" {
+			supplier, err := resolveContact(client, slug, purchasesSupplier)
 			if err != nil {
-				return fmt.Errorf("fetching purchases: %w", err)
+				return err
 			}
-			purchases = append(purchases, pagePurchases...)
+			supplierID = supplier.ContactId
+		}
 
-			if pagination == nil || page+1 >= pagination.PageCount || len(pagePurchases) == 0 {
-				break
-			}
-			page++
-			// Only fetch first few pages by default
-			if page >= 4 {
-				break
-			}
+		projectFilter, err := resolveProjectID(client, slug, purchasesProject)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointPurchases, slug)
+
+		// By default only the first pages are fetched; --all and --limit lift
+		// the cap. Local filters need every fetched page, so the limit is
+		// applied after filtering.
+		localFilter := purchasesPaid || purchasesUnpaid || supplierID != 0 || projectFilter != 0
+		capped := !purchasesAll && purchasesLimit <= 0
+		fetchLimit := purchasesLimit
+		if localFilter {
+			fetchLimit = 0
+		}
+		pageSize := clampPageSize(purchasesPageSize)
+		if capped {
+			fetchLimit = purchasesDefaultPages * pageSize
+		}
+		list := api.NewList[api.Purchase](client, endpoint, params).PageSize(pageSize).Limit(fetchLimit)
+		purchases, err := list.Collect()
+		if err != nil {
+			return fmt.Errorf("fetching purchases: %w", err)
+		}
+		truncated := capped && list.ResultCount() > len(purchases)
+
+		if localFilter {
+			purchases = filterItems(purchases, func(p api.Purchase) bool {
+				if (purchasesPaid && !p.Paid) || (purchasesUnpaid && p.Paid) {
+					return false
+				}
+				if supplierID != 0 && p.Supplier.ContactId != supplierID {
+					return false
+				}
+				return projectFilter == 0 || projectIDOf(p.Project) == projectFilter
+			}, purchasesLimit)
 		}
 
 		if jsonOutput {
@@ -61,32 +176,134 @@ var purchasesListCmd = &cobra.Command{
 
 		if len(purchases) == 0 {
 			output.PrintInfo("No purchases found.")
+			if truncated {
+				output.PrintInfo("Only the first pages were searched; use --all to search every purchase.")
+			}
 			return nil
 		}
 
-		table := output.NewTable("ID", "DATE", "KIND", "PAID", "AMOUNT", "IDENTIFIER")
+		table := output.NewTable("ID", "DATE", "KIND", "SUPPLIER", "IDENTIFIER", "DUE", "GROSS", "PAID")
 		for _, p := range purchases {
-			paid := "No"
-			if p.Paid {
-				paid = "Yes"
-			}
-			// Sum net amounts from lines
-			var totalNet int64
-			for _, l := range p.Lines {
-				totalNet += l.NetAmount
-			}
 			table.AddRow(
 				fmt.Sprintf("%d", p.PurchaseId),
 				p.Date,
 				p.Kind,
-				paid,
-				output.FormatAmount(totalNet),
+				p.Supplier.Name,
 				p.Identifier,
+				p.DueDate,
+				output.FormatAmount(purchaseGross(p)),
+				yesNo(p.Paid),
 			)
 		}
 		table.Print()
 
 		fmt.Printf("\n%d purchases\n", len(purchases))
+		if truncated {
+			output.PrintInfo("Only the first pages were fetched; use --all or --limit to see more.")
+		}
+		return nil
+	},
+}
+
+var purchasesGetCmd = &cobra.Command{
+	Use:   "get <purchaseId>",
+	Short: "Show a purchase",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		purchaseID, err := parseID(args[0], "purchase")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var purchase api.Purchase
+		_, err = client.Get(fmt.Sprintf(api.EndpointPurchase, slug, purchaseID), &purchase)
+		if err != nil {
+			return fmt.Errorf("fetching purchase: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(purchase)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", purchase.PurchaseId))
+		d.Add("Identifier", purchase.Identifier)
+		d.Add("Date", purchase.Date)
+		d.Add("Kind", purchase.Kind)
+		d.Add("Supplier", purchase.Supplier.Name)
+		d.Add("Project", projectLabel(purchase.Project))
+		d.Add("Due date", purchase.DueDate)
+		d.Add("KID", purchase.Kid)
+		d.Add("Currency", purchase.Currency)
+		d.Add("Payment account", purchase.PaymentAccount)
+		d.Add("Payment date", purchase.PaymentDate)
+		d.Add("Total paid", output.FormatAmount(purchase.TotalPaid))
+		d.Add("Paid", yesNo(purchase.Paid))
+		if purchase.TransactionId != 0 {
+			d.Add("Transaction ID", fmt.Sprintf("%d", purchase.TransactionId))
+		}
+		if purchase.Deleted {
+			d.Add("Deleted", "Yes")
+		}
+		d.Print()
+
+		fmt.Println()
+		printOrderLines(purchase.Lines)
+
+		if len(purchase.Attachments) > 0 {
+			fmt.Println()
+			printAttachments(purchase.Attachments)
+		}
+		return nil
+	},
+}
+
+var purchasesDeleteCmd = &cobra.Command{
+	Use:   "delete <purchaseId>",
+	Short: "Delete a purchase",
+	Long:  "Delete a purchase. Fiken requires a description of why the purchase is deleted.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		purchaseID, err := parseID(args[0], "purchase")
+		if err != nil {
+			return err
+		}
+		if purchasesDescription == "" {
+			return fmt.Errorf("--description is required")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Delete purchase %d?", purchaseID)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		params := url.Values{"description": {purchasesDescription}}
+		endpoint := fmt.Sprintf(api.EndpointPurchaseDelete, slug, purchaseID) + "?" + params.Encode()
+		if err := client.Patch(endpoint, nil, nil); err != nil {
+			return fmt.Errorf("deleting purchase: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Purchase %d deleted", purchaseID))
 		return nil
 	},
 }
@@ -94,14 +311,300 @@ var purchasesListCmd = &cobra.Command{
 var purchasesCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a purchase",
+	Long: `Create a purchase from flags, a JSON/YAML file, or both.
+
+Kinds:
+  cash_purchase  Paid right away; needs a payment account (bank account or
+                 a ledger account such as 1900). Defaults to the only active
+                 bank account. Has no due date.
+  supplier       Bought on credit; needs a supplier and a due date, and has
+                 no payment account. Register the payment later with
+                 'fiken purchases payments add'.
+
+Lines are given with --line as comma-separated key=value pairs:
+  description (desc), account, net or gross (in kroner),
+  vatAmount, vatType (vat, default HIGH).
+If only gross is given, net and VAT are calculated from the VAT type.
+Line accounts and VAT types are checked before the purchase is created.
+
+A receipt can be attached in the same command with --attach.`,
+	Example: `  fiken purchases create --kind cash_purchase --date 2024-01-15 \
+    --line "desc=Office chair,account=6540,gross=2490" --attach receipt.pdf
+  fiken purchases create --kind supplier --supplier "Acme AS" --identifier 10045 \
+    --due-date 2024-02-14 --line "desc=Hosting January,account=6810,net=800"
+  fiken purchases create --file purchase.yaml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Not yet implemented. Use the Fiken web UI to create purchases for now.")
+		spec := purchaseSpec{}
+		if purchaseCreateInput.file != "" {
+			if err := readInputFile(purchaseCreateInput.file, &spec); err != nil {
+				return err
+			}
+		}
+		if err := applyPurchaseCreateFlags(cmd, &spec); err != nil {
+			return err
+		}
+		if spec.Attachment != "" {
+			if _, err := os.Stat(spec.Attachment); err != nil {
+				return fmt.Errorf("attachment: %w", err)
+			}
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		purchaseID, err := createPurchase(client, slug, spec)
+		if err != nil {
+			return err
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"purchaseId": purchaseID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Purchase created (ID %d)", purchaseID))
+		if spec.Attachment != "" {
+			output.PrintSuccess(fmt.Sprintf("Attached %s", filepath.Base(spec.Attachment)))
+		}
 		return nil
 	},
 }
 
+// createPurchase creates a purchase from spec and uploads its attachment,
+// if any. If the upload fails the purchase has already been created.
+func createPurchase(client *api.Client, slug string, spec purchaseSpec) (int64, error) {
+	req, err := buildPurchaseRequest(client, slug, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	purchaseID, err := client.PostForID(fmt.Sprintf(api.EndpointPurchases, slug), req)
+	if err != nil {
+		return 0, fmt.Errorf("creating purchase: %w", err)
+	}
+
+	if spec.Attachment != "" {
+		endpoint := fmt.Sprintf(api.EndpointPurchaseAttachments, slug, purchaseID)
+		if err := uploadFile(client, endpoint, spec.Attachment, nil); err != nil {
+			return purchaseID, fmt.Errorf("purchase %d created, but attaching %s failed: %w", purchaseID, spec.Attachment, err)
+		}
+	}
+	return purchaseID, nil
+}
+
+// applyPurchaseCreateFlags copies the purchases create flags that were set
+// on cmd into spec, overriding values from the input file.
+func applyPurchaseCreateFlags(cmd *cobra.Command, spec *purchaseSpec) error {
+	flags := cmd.Flags()
+	if flags.Changed("kind") {
+		spec.Kind = purchaseCreateInput.kind
+	}
+	if flags.Changed("date") {
+		spec.Date = purchaseCreateInput.date
+	}
+	if flags.Changed("due-date") {
+		spec.DueDate = purchaseCreateInput.dueDate
+	}
+	if flags.Changed("identifier") {
+		spec.Identifier = purchaseCreateInput.identifier
+	}
+	if flags.Changed("supplier") {
+		spec.Supplier = idOrName(purchaseCreateInput.supplier)
+	}
+	if flags.Changed("currency") {
+		spec.Currency = purchaseCreateInput.currency
+	}
+	if flags.Changed("payment-account") {
+		spec.PaymentAccount = purchaseCreateInput.paymentAccount
+	}
+	if flags.Changed("payment-date") {
+		spec.PaymentDate = purchaseCreateInput.paymentDate
+	}
+	if flags.Changed("kid") {
+		spec.Kid = purchaseCreateInput.kid
+	}
+	if flags.Changed("attach") {
+		spec.Attachment = purchaseCreateInput.attach
+	}
+	if flags.Changed("project") {
+		spec.Project = idOrName(purchaseCreateInput.project)
+	}
+	for _, l := range purchaseCreateInput.lines {
+		line, err := parseOrderLine(l)
+		if err != nil {
+			return err
+		}
+		spec.Lines = append(spec.Lines, line)
+	}
+	return nil
+}
+
+// buildPurchaseRequest validates spec against its kind, checks line
+// accounts and VAT types, resolves the supplier and payment account, and
+// converts it into an API request.
+func buildPurchaseRequest(client *api.Client, slug string, spec purchaseSpec) (api.PurchaseRequest, error) {
+	if !slices.Contains(purchaseKinds, spec.Kind) {
+		return api.PurchaseRequest{}, fmt.Errorf("invalid kind %q (valid: cash_purchase, supplier)", spec.Kind)
+	}
+	if len(spec.Lines) == 0 {
+		return api.PurchaseRequest{}, fmt.Errorf("at least one line is required (--line)")
+	}
+
+	date := spec.Date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if err := validateDate(date); err != nil {
+		return api.PurchaseRequest{}, err
+	}
+
+	currency := spec.Currency
+	if currency == "" {
+		currency = "NOK"
+	}
+
+	req := api.PurchaseRequest{
+		Date:       date,
+		Kind:       spec.Kind,
+		Currency:   currency,
+		Identifier: spec.Identifier,
+		Kid:        spec.Kid,
+	}
+
+	var accounts []string
+	for i, l := range spec.Lines {
+		if l.Product != 0 || l.Quantity != 0 {
+			return req, fmt.Errorf("line %d: products can only be used on sale lines", i+1)
+		}
+		line, err := toOrderLine(l)
+		if err != nil {
+			return req, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if !slices.Contains(purchaseVatTypes, line.VatType) {
+			return req, fmt.Errorf("line %d: invalid VAT type %q for a purchase (valid: %s)",
+				i+1, line.VatType, strings.Join(purchaseVatTypes, ", "))
+		}
+		req.Lines = append(req.Lines, line)
+		accounts = append(accounts, line.Account)
+	}
+
+	switch spec.Kind {
+	case "cash_purchase":
+		if spec.DueDate != "" {
+			return req, fmt.Errorf("a cash purchase has no due date; use --kind supplier for purchases on credit")
+		}
+		account, err := resolvePaymentAccount(client, slug, spec.PaymentAccount)
+		if err != nil {
+			return req, err
+		}
+		req.PaymentAccount = account
+		req.PaymentDate = spec.PaymentDate
+		if req.PaymentDate == "" {
+			req.PaymentDate = date
+		}
+		if err := validateDate(req.PaymentDate); err != nil {
+			return req, err
+		}
+	case "supplier":
+		if spec.Supplier == "" {
+			return req, fmt.Errorf("a supplier purchase needs a supplier (--supplier)")
+		}
+		if spec.DueDate == "" {
+			return req, fmt.Errorf("a supplier purchase needs a due date (--due-date)")
+		}
+		if spec.PaymentAccount != "" || spec.PaymentDate != "" {
+			return req, fmt.Errorf("a supplier purchase has no payment account; register the payment with 'fiken purchases payments add'")
+		}
+		if err := validateDate(spec.DueDate); err != nil {
+			return req, err
+		}
+		req.DueDate = spec.DueDate
+	}
+
+	if err := validateAccounts(client, slug, accounts); err != nil {
+		return req, err
+	}
+
+	if spec.Supplier != "" {
+		supplier, err := resolveContact(client, slug, string(spec.Supplier))
+		if err != nil {
+			return req, err
+		}
+		req.SupplierId = supplier.ContactId
+	}
+
+	projectID, err := resolveProjectID(client, slug, string(spec.Project))
+	if err != nil {
+		return req, err
+	}
+	req.ProjectId = projectID
+
+	return req, nil
+}
+
+// purchaseOutstanding returns the unpaid amount and currency of a purchase.
+func purchaseOutstanding(client *api.Client, slug string, purchaseID int64) (int64, string, error) {
+	var purchase api.Purchase
+	_, err := client.Get(fmt.Sprintf(api.EndpointPurchase, slug, purchaseID), &purchase)
+	if err != nil {
+		return 0, "", fmt.Errorf("fetching purchase: %w", err)
+	}
+	return purchaseGross(purchase) - purchase.TotalPaid, purchase.Currency, nil
+}
+
+// purchaseGross returns the gross amount of a purchase from its lines.
+func purchaseGross(p api.Purchase) int64 {
+	var gross int64
+	for _, l := range p.Lines {
+		gross += l.NetAmount + l.VatAmount
+	}
+	return gross
+}
+
+// addPurchaseCreateFlags registers the flags of purchases create, which
+// inbox to-purchase shares.
+func addPurchaseCreateFlags(cmd *cobra.Command) {
+	f := cmd.Flags()
+	f.StringVarP(&purchaseCreateInput.file, "file", "f", "", "Read purchase from a JSON or YAML file (- for stdin)")
+	f.StringVar(&purchaseCreateInput.kind, "kind", "", "Purchase kind: cash_purchase or supplier")
+	f.StringVar(&purchaseCreateInput.date, "date", "", "Purchase date (YYYY-MM-DD, default today)")
+	f.StringVar(&purchaseCreateInput.dueDate, "due-date", "", "Due date for supplier purchases (YYYY-MM-DD)")
+	f.StringVar(&purchaseCreateInput.identifier, "identifier", "", "Supplier's invoice number")
+	f.StringVar(&purchaseCreateInput.supplier, "supplier", "", "Supplier contact ID or name")
+	f.StringVar(&purchaseCreateInput.currency, "currency", "", "Currency (default NOK)")
+	f.StringVar(&purchaseCreateInput.paymentAccount, "payment-account", "", "Payment account for cash purchases (e.g. 1920:10001 or 1900)")
+	f.StringVar(&purchaseCreateInput.paymentDate, "payment-date", "", "Payment date for cash purchases (default purchase date)")
+	f.StringVar(&purchaseCreateInput.kid, "kid", "", "KID number for the payment")
+	f.StringVar(&purchaseCreateInput.attach, "attach", "", "Receipt file to attach (PDF or image)")
+	f.StringVar(&purchaseCreateInput.project, "project", "", "Project ID, number or name")
+	f.StringArrayVar(&purchaseCreateInput.lines, "line", nil, "Purchase line as key=value pairs (repeatable)")
+}
+
 func init() {
+	purchasesListCmd.Flags().StringVar(&purchasesFrom, "from", "", "Date from (YYYY-MM-DD)")
+	purchasesListCmd.Flags().StringVar(&purchasesTo, "to", "", "Date to (YYYY-MM-DD)")
+	purchasesListCmd.Flags().BoolVar(&purchasesPaid, "paid", false, "Only paid purchases")
+	purchasesListCmd.Flags().BoolVar(&purchasesUnpaid, "unpaid", false, "Only unpaid purchases")
+	purchasesListCmd.Flags().StringVar(&purchasesSupplier, "supplier", "", "Filter by supplier contact ID or name")
+	purchasesListCmd.Flags().StringVar(&purchasesProject, "project", "", "Filter by project ID, number or name")
+	purchasesListCmd.Flags().BoolVar(&purchasesAll, "all", false, "Fetch all pages instead of only the first 4")
+	purchasesListCmd.Flags().IntVar(&purchasesLimit, "limit", 0, "Maximum number of purchases to show (0 = all)")
+	purchasesListCmd.Flags().IntVar(&purchasesPageSize, "page-size", 25, "Number of purchases to fetch per request")
+
+	purchasesDeleteCmd.Flags().StringVar(&purchasesDescription, "description", "", "Reason for deleting the purchase (required)")
+
+	addPurchaseCreateFlags(purchasesCreateCmd)
+
 	purchasesCmd.AddCommand(purchasesListCmd)
+	purchasesCmd.AddCommand(purchasesGetCmd)
 	purchasesCmd.AddCommand(purchasesCreateCmd)
+	purchasesCmd.AddCommand(purchasesDeleteCmd)
+	purchasesCmd.AddCommand(newPaymentsCmd("purchase", api.EndpointPurchasePayments, purchaseOutstanding))
+	purchasesCmd.AddCommand(newAttachmentsCmd("purchase", "purchases", api.EndpointPurchaseAttachments))
 	rootCmd.AddCommand(purchasesCmd)
 }
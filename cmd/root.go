@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/jakoblind/fiken-cli/api"
 	"github.com/jakoblind/fiken-cli/auth"
+	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +21,7 @@ var (
 	noInput        bool
 	company        string
 	keyringBackend string
+	maxRetries     int
 )
 
 var rootCmd = &cobra.Command{
@@ -30,9 +37,23 @@ companies, purchases, invoices, bank accounts, and more.`,
 	},
 }
 
-// Execute runs the root command.
+// Execute runs the root command. An interrupt (Ctrl-C) or SIGTERM cancels
+// the command's context, which stops the API request in flight; a second
+// interrupt kills the process.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	stop()
+	if err != nil {
+		if interrupted {
+			os.Exit(130)
+		}
 		os.Exit(1)
 	}
 }
@@ -43,6 +64,8 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&company, "company", "", "Company slug (auto-detected if only one)")
 	rootCmd.PersistentFlags().StringVar(&keyringBackend, "keyring-backend", "auto",
 		"Keyring backend: auto, secret-service, keychain, wincred, pass, file")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", api.DefaultRetryPolicy.MaxRetries,
+		"Retries of API requests that fail with 429, 5xx or a network error (0 to disable)")
 
 	// Support FIKEN_KEYRING_BACKEND env var as default.
 	if env := os.Getenv("FIKEN_KEYRING_BACKEND"); env != "" {
@@ -60,7 +83,20 @@ func getClient() (*api.Client, error) {
 	if token == "" {
 		return nil, fmt.Errorf("token is empty. Run 'fiken auth token <token>' to set up authentication")
 	}
-	return api.NewClient(token), nil
+	if maxRetries < 0 {
+		return nil, fmt.Errorf("--max-retries must not be negative")
+	}
+
+	retry := api.DefaultRetryPolicy
+	retry.MaxRetries = maxRetries
+	retry.OnRetry = func(req *http.Request, err error, attempt int, wait time.Duration) {
+		output.PrintWarning(fmt.Sprintf("%s %s: %v; retrying in %s (%d/%d)",
+			req.Method, req.URL.Path, err, wait.Round(100*time.Millisecond), attempt, maxRetries))
+	}
+
+	// Bind the client to the command context so that Ctrl-C cancels
+	// requests and rate-limit and retry waits.
+	return api.NewClient(token).WithContext(rootCmd.Context()).WithRetryPolicy(retry), nil
 }
 
 // resolveCompany determines which company to use.
@@ -76,8 +112,7 @@ func resolveCompany(client *api.Client) (string, error) {
 	}
 
 	// Auto-detect: fetch companies and use the only one if there's just one.
-	var companies []api.Company
-	_, err = client.Get(api.EndpointCompanies, &companies)
+	companies, err := fetchPages[api.Company](client, api.EndpointCompanies, nil, api.MaxPageSize, 0)
 	if err != nil {
 		return "", fmt.Errorf("fetching companies: %w", err)
 	}
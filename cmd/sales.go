@@ -2,25 +2,551 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	salesFrom        string
+	salesTo          string
+	salesPaid        bool
+	salesUnpaid      bool
+	salesContact     int64
+	salesProject     string
+	salesLimit       int
+	salesPageSize    int
+	salesDescription string
+)
+
+// saleCreateInput holds the flags for sales create.
+var saleCreateInput struct {
+	file           string
+	kind           string
+	date           string
+	saleNumber     string
+	customer       string
+	currency       string
+	dueDate        string
+	kid            string
+	paymentAccount string
+	paymentDate    string
+	project        string
+	lines          []string
+	products       []int64
+}
+
+// saleKinds are the sale kinds that can be created from the CLI. Invoice
+// sales are created with 'fiken invoices create'.
+var saleKinds = []string{"cash_sale", "external_invoice"}
+
+// saleSpec is the file format accepted by sales create --file.
+type saleSpec struct {
+	Kind           string          `json:"kind"`
+	Date           string          `json:"date"`
+	SaleNumber     string          `json:"saleNumber"`
+	Customer       idOrName        `json:"customer"`
+	Currency       string          `json:"currency"`
+	DueDate        string          `json:"dueDate"`
+	Kid            string          `json:"kid"`
+	PaymentAccount string          `json:"paymentAccount"`
+	PaymentDate    string          `json:"paymentDate"`
+	Project        idOrName        `json:"project"`
+	Lines          []orderLineSpec `json:"lines"`
+}
+
 var salesCmd = &cobra.Command{
 	Use:   "sales",
 	Short: "Manage sales",
+	Long:  "List, inspect, create and delete sales.",
 }
 
 var salesListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List sales",
+	Example: `  fiken sales list --from 2024-01-01 --to 2024-01-31
+  fiken sales list --unpaid --contact 123`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if salesPaid && salesUnpaid {
+			return fmt.Errorf("--paid and --unpaid cannot be combined")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		params := url.Values{}
+		if salesFrom != "" {
+			if err := validateDate(salesFrom); err != nil {
+				return err
+			}
+			params.Set("dateGe", salesFrom)
+		}
+		if salesTo != "" {
+			if err := validateDate(salesTo); err != nil {
+				return err
+			}
+			params.Set("dateLe", salesTo)
+		}
+		if salesPaid || salesUnpaid {
+			params.Set("settled", strconv.FormatBool(salesPaid))
+		}
+
+		projectFilter, err := resolveProjectID(client, slug, salesProject)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointSales, slug)
+
+		// The contact and project filters are applied locally, so the limit is applied afterwards.
+		localFilter := salesContact != 0 || projectFilter != 0
+		limit := salesLimit
+		if localFilter {
+			limit = 0
+		}
+		sales, err := fetchPages[api.Sale](client, endpoint, params, salesPageSize, limit)
+		if err != nil {
+			return fmt.Errorf("fetching sales: %w", err)
+		}
+
+		if localFilter {
+			sales = filterItems(sales, func(s api.Sale) bool {
+				if salesContact != 0 && s.Customer.ContactId != salesContact {
+					return false
+				}
+				return projectFilter == 0 || projectIDOf(s.Project) == projectFilter
+			}, salesLimit)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(sales)
+		}
+
+		if len(sales) == 0 {
+			output.PrintInfo("No sales found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "NUMBER", "DATE", "KIND", "CUSTOMER", "GROSS", "OUTSTANDING", "PAID")
+		for _, s := range sales {
+			table.AddRow(
+				fmt.Sprintf("%d", s.SaleId),
+				s.SaleNumber,
+				s.Date,
+				s.Kind,
+				s.Customer.Name,
+				output.FormatAmount(saleGross(s)),
+				output.FormatAmount(s.OutstandingBalance),
+				yesNo(s.Settled),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d sales\n", len(sales))
+		return nil
+	},
+}
+
+var salesGetCmd = &cobra.Command{
+	Use:   "get <saleId>",
+	Short: "Show a sale",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		saleID, err := parseID(args[0], "sale")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var sale api.Sale
+		_, err = client.Get(fmt.Sprintf(api.EndpointSale, slug, saleID), &sale)
+		if err != nil {
+			return fmt.Errorf("fetching sale: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(sale)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", sale.SaleId))
+		d.Add("Number", sale.SaleNumber)
+		d.Add("Date", sale.Date)
+		d.Add("Kind", sale.Kind)
+		d.Add("Customer", sale.Customer.Name)
+		d.Add("Project", projectLabel(sale.Project))
+		d.Add("Due date", sale.DueDate)
+		d.Add("KID", sale.Kid)
+		d.Add("Currency", sale.Currency)
+		d.Add("Payment account", sale.PaymentAccount)
+		d.Add("Total paid", output.FormatAmount(sale.TotalPaid))
+		d.Add("Outstanding", output.FormatAmount(sale.OutstandingBalance))
+		d.Add("Paid", yesNo(sale.Settled))
+		d.Add("Settled date", sale.SettledDate)
+		if sale.TransactionId != 0 {
+			d.Add("Transaction ID", fmt.Sprintf("%d", sale.TransactionId))
+		}
+		d.Print()
+
+		fmt.Println()
+		printOrderLines(sale.Lines)
+		return nil
+	},
+}
+
+var salesCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a cash sale or external invoice sale",
+	Long: `Create a sale from flags, a JSON/YAML file, or both.
+
+Kinds:
+  cash_sale         Paid right away; needs a payment account (bank account
+                    or a ledger account such as 1900). Defaults to the only
+                    active bank account.
+  external_invoice  Invoiced outside Fiken; needs a customer and a due date.
+
+Lines are given with --line as comma-separated key=value pairs:
+  description (desc), account, net or gross (in kroner),
+  vatAmount, vatType (vat, default HIGH), product, quantity (qty).
+If only gross is given, net and VAT are calculated from the VAT type.
+A line with a product ID takes its description, income account, VAT type
+and net amount (unit price times quantity) from the product unless they
+are given. --product <id> adds a line with one unit of a product.`,
+	Example: `  fiken sales create --kind cash_sale --date 2024-01-31 \
+    --line "desc=Webshop sales 2024-01-31,account=3000,gross=12450"
+  fiken sales create --kind external_invoice --customer "Acme AS" \
+    --due-date 2024-02-14 --sale-number 1001 --line "desc=Consulting,account=3000,net=10000"
+  fiken sales create --kind cash_sale --product 42 --line "product=43,qty=3"
+  fiken sales create --file sale.yaml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Not yet implemented.")
+		spec := saleSpec{}
+		if saleCreateInput.file != "" {
+			if err := readInputFile(saleCreateInput.file, &spec); err != nil {
+				return err
+			}
+		}
+		if err := applySaleCreateFlags(cmd, &spec); err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		req, err := buildSaleRequest(client, slug, spec)
+		if err != nil {
+			return err
+		}
+
+		saleID, err := client.PostForID(fmt.Sprintf(api.EndpointSales, slug), req)
+		if err != nil {
+			return fmt.Errorf("creating sale: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(map[string]int64{"saleId": saleID})
+		}
+		output.PrintSuccess(fmt.Sprintf("Sale created (ID %d)", saleID))
+		return nil
+	},
+}
+
+var salesDeleteCmd = &cobra.Command{
+	Use:   "delete <saleId>",
+	Short: "Delete a sale",
+	Long:  "Delete a sale. Fiken requires a description of why the sale is deleted.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		saleID, err := parseID(args[0], "sale")
+		if err != nil {
+			return err
+		}
+		if salesDescription == "" {
+			return fmt.Errorf("--description is required")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Delete sale %d?", saleID)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		params := url.Values{"description": {salesDescription}}
+		endpoint := fmt.Sprintf(api.EndpointSaleDelete, slug, saleID) + "?" + params.Encode()
+		if err := client.Patch(endpoint, nil, nil); err != nil {
+			return fmt.Errorf("deleting sale: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Sale %d deleted", saleID))
 		return nil
 	},
 }
 
+// applySaleCreateFlags copies the sales create flags that were set on cmd
+// into spec, overriding values from the input file.
+func applySaleCreateFlags(cmd *cobra.Command, spec *saleSpec) error {
+	flags := cmd.Flags()
+	if flags.Changed("kind") {
+		spec.Kind = saleCreateInput.kind
+	}
+	if flags.Changed("date") {
+		spec.Date = saleCreateInput.date
+	}
+	if flags.Changed("sale-number") {
+		spec.SaleNumber = saleCreateInput.saleNumber
+	}
+	if flags.Changed("customer") {
+		spec.Customer = idOrName(saleCreateInput.customer)
+	}
+	if flags.Changed("currency") {
+		spec.Currency = saleCreateInput.currency
+	}
+	if flags.Changed("due-date") {
+		spec.DueDate = saleCreateInput.dueDate
+	}
+	if flags.Changed("kid") {
+		spec.Kid = saleCreateInput.kid
+	}
+	if flags.Changed("payment-account") {
+		spec.PaymentAccount = saleCreateInput.paymentAccount
+	}
+	if flags.Changed("payment-date") {
+		spec.PaymentDate = saleCreateInput.paymentDate
+	}
+	if flags.Changed("project") {
+		spec.Project = idOrName(saleCreateInput.project)
+	}
+	for _, l := range saleCreateInput.lines {
+		line, err := parseOrderLine(l)
+		if err != nil {
+			return err
+		}
+		spec.Lines = append(spec.Lines, line)
+	}
+	for _, id := range saleCreateInput.products {
+		spec.Lines = append(spec.Lines, orderLineSpec{Product: id})
+	}
+	return nil
+}
+
+// buildSaleRequest validates spec against its kind, resolves the customer
+// and payment account, and converts it into an API request.
+func buildSaleRequest(client *api.Client, slug string, spec saleSpec) (api.SaleRequest, error) {
+	if spec.Kind == "" {
+		spec.Kind = "cash_sale"
+	}
+	if !slices.Contains(saleKinds, spec.Kind) {
+		return api.SaleRequest{}, fmt.Errorf("invalid kind %q (valid: cash_sale, external_invoice)", spec.Kind)
+	}
+	if len(spec.Lines) == 0 {
+		return api.SaleRequest{}, fmt.Errorf("at least one line is required (--line)")
+	}
+
+	date := spec.Date
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+	if err := validateDate(date); err != nil {
+		return api.SaleRequest{}, err
+	}
+
+	currency := spec.Currency
+	if currency == "" {
+		currency = "NOK"
+	}
+
+	req := api.SaleRequest{
+		SaleNumber: spec.SaleNumber,
+		Date:       date,
+		Kind:       spec.Kind,
+		Currency:   currency,
+		Kid:        spec.Kid,
+	}
+
+	switch spec.Kind {
+	case "cash_sale":
+		if spec.DueDate != "" {
+			return req, fmt.Errorf("a cash sale has no due date")
+		}
+		account, err := resolvePaymentAccount(client, slug, spec.PaymentAccount)
+		if err != nil {
+			return req, err
+		}
+		req.PaymentAccount = account
+		req.PaymentDate = spec.PaymentDate
+		if req.PaymentDate == "" {
+			req.PaymentDate = date
+		}
+		if err := validateDate(req.PaymentDate); err != nil {
+			return req, err
+		}
+	case "external_invoice":
+		if spec.Customer == "" {
+			return req, fmt.Errorf("an external invoice sale needs a customer (--customer)")
+		}
+		if spec.DueDate == "" {
+			return req, fmt.Errorf("an external invoice sale needs a due date (--due-date)")
+		}
+		if spec.PaymentAccount != "" {
+			return req, fmt.Errorf("an external invoice sale has no payment account; register payments with 'fiken sales payments add'")
+		}
+		if err := validateDate(spec.DueDate); err != nil {
+			return req, err
+		}
+		req.DueDate = spec.DueDate
+	}
+
+	if spec.Customer != "" {
+		customer, err := resolveContact(client, slug, string(spec.Customer))
+		if err != nil {
+			return req, err
+		}
+		req.CustomerId = customer.ContactId
+	}
+
+	projectID, err := resolveProjectID(client, slug, string(spec.Project))
+	if err != nil {
+		return req, err
+	}
+	req.ProjectId = projectID
+
+	for i, l := range spec.Lines {
+		if l.Product != 0 {
+			product, err := fetchProduct(client, slug, l.Product)
+			if err != nil {
+				return req, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			l = orderLineFromProduct(l, product)
+		} else if l.Quantity != 0 {
+			return req, fmt.Errorf("line %d: quantity can only be used with a product", i+1)
+		}
+		line, err := toOrderLine(l)
+		if err != nil {
+			return req, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		req.Lines = append(req.Lines, line)
+	}
+	return req, nil
+}
+
+// saleOutstanding returns the unpaid amount and currency of a sale.
+func saleOutstanding(client *api.Client, slug string, saleID int64) (int64, string, error) {
+	var sale api.Sale
+	_, err := client.Get(fmt.Sprintf(api.EndpointSale, slug, saleID), &sale)
+	if err != nil {
+		return 0, "", fmt.Errorf("fetching sale: %w", err)
+	}
+	if sale.OutstandingBalance != 0 {
+		return sale.OutstandingBalance, sale.Currency, nil
+	}
+	return saleGross(sale) - sale.TotalPaid, sale.Currency, nil
+}
+
+// saleGross returns the gross amount of a sale, falling back to its lines.
+func saleGross(s api.Sale) int64 {
+	if s.NetAmount != 0 || s.VatAmount != 0 {
+		return s.NetAmount + s.VatAmount
+	}
+	var gross int64
+	for _, l := range s.Lines {
+		gross += l.NetAmount + l.VatAmount
+	}
+	return gross
+}
+
+func printOrderLines(lines []api.OrderLine) {
+	table := output.NewTable("DESCRIPTION", "ACCOUNT", "NET", "VAT", "GROSS", "VAT TYPE")
+	var net, vat int64
+	for _, l := range lines {
+		net += l.NetAmount
+		vat += l.VatAmount
+		table.AddRow(
+			l.Description,
+			l.Account,
+			output.FormatAmount(l.NetAmount),
+			output.FormatAmount(l.VatAmount),
+			output.FormatAmount(l.NetAmount+l.VatAmount),
+			l.VatType,
+		)
+	}
+	table.Print()
+
+	fmt.Println()
+	totals := output.NewDetails()
+	totals.Add("Net", output.FormatAmount(net))
+	totals.Add("VAT", output.FormatAmount(vat))
+	totals.Add("Gross", output.FormatAmount(net+vat))
+	totals.Print()
+}
+
 func init() {
+	salesListCmd.Flags().StringVar(&salesFrom, "from", "", "Date from (YYYY-MM-DD)")
+	salesListCmd.Flags().StringVar(&salesTo, "to", "", "Date to (YYYY-MM-DD)")
+	salesListCmd.Flags().BoolVar(&salesPaid, "paid", false, "Only paid (settled) sales")
+	salesListCmd.Flags().BoolVar(&salesUnpaid, "unpaid", false, "Only unpaid sales")
+	salesListCmd.Flags().Int64Var(&salesContact, "contact", 0, "Filter by customer contact ID")
+	salesListCmd.Flags().StringVar(&salesProject, "project", "", "Filter by project ID, number or name")
+	salesListCmd.Flags().IntVar(&salesLimit, "limit", 0, "Maximum number of sales to show (0 = all)")
+	salesListCmd.Flags().IntVar(&salesPageSize, "page-size", api.MaxPageSize, "Number of sales to fetch per request")
+
+	f := salesCreateCmd.Flags()
+	f.StringVarP(&saleCreateInput.file, "file", "f", "", "Read sale from a JSON or YAML file (- for stdin)")
+	f.StringVar(&saleCreateInput.kind, "kind", "cash_sale", "Sale kind: cash_sale or external_invoice")
+	f.StringVar(&saleCreateInput.date, "date", "", "Sale date (YYYY-MM-DD, default today)")
+	f.StringVar(&saleCreateInput.saleNumber, "sale-number", "", "Sale or invoice number")
+	f.StringVar(&saleCreateInput.customer, "customer", "", "Customer contact ID or name")
+	f.StringVar(&saleCreateInput.currency, "currency", "", "Currency (default NOK)")
+	f.StringVar(&saleCreateInput.dueDate, "due-date", "", "Due date for external invoices (YYYY-MM-DD)")
+	f.StringVar(&saleCreateInput.kid, "kid", "", "KID number")
+	f.StringVar(&saleCreateInput.paymentAccount, "payment-account", "", "Payment account for cash sales (e.g. 1920:10001 or 1900)")
+	f.StringVar(&saleCreateInput.paymentDate, "payment-date", "", "Payment date for cash sales (default sale date)")
+	f.StringVar(&saleCreateInput.project, "project", "", "Project ID, number or name")
+	f.StringArrayVar(&saleCreateInput.lines, "line", nil, "Sale line as key=value pairs (repeatable)")
+	f.Int64SliceVar(&saleCreateInput.products, "product", nil, "Add a line for a product ID with its defaults (repeatable)")
+
+	salesDeleteCmd.Flags().StringVar(&salesDescription, "description", "", "Reason for deleting the sale (required)")
+
 	salesCmd.AddCommand(salesListCmd)
+	salesCmd.AddCommand(salesGetCmd)
+	salesCmd.AddCommand(salesCreateCmd)
+	salesCmd.AddCommand(salesDeleteCmd)
+	salesCmd.AddCommand(newPaymentsCmd("sale", api.EndpointSalePayments, saleOutstanding))
+	salesCmd.AddCommand(newAttachmentsCmd("sale", "sales", api.EndpointSaleAttachments))
 	rootCmd.AddCommand(salesCmd)
 }
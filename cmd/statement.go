@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// statementLine is a booked transaction on a bank statement. Amount is in
+// øre; incoming payments are positive and outgoing payments negative.
+type statementLine struct {
+	Date         string `json:"date"`
+	Amount       int64  `json:"amount"`
+	Currency     string `json:"currency,omitempty"`
+	Reference    string `json:"reference,omitempty"`
+	Counterparty string `json:"counterparty,omitempty"`
+	Text         string `json:"text,omitempty"`
+}
+
+// csvFormat describes the columns of a CSV bank statement. Columns are
+// given by header name or by 1-based column number; empty columns are
+// detected from the header row using the names Norwegian banks use.
+// Either Amount or In/Out must be found.
+type csvFormat struct {
+	Delimiter    string `json:"delimiter"`
+	DateFormat   string `json:"dateFormat"`
+	Date         string `json:"date"`
+	Amount       string `json:"amount"`
+	In           string `json:"in"`
+	Out          string `json:"out"`
+	Text         string `json:"text"`
+	Reference    string `json:"reference"`
+	Counterparty string `json:"counterparty"`
+}
+
+// csvColumnNames are the header names tried, ignoring case, for columns
+// not set in a csvFormat.
+var csvColumnNames = map[string][]string{
+	"date":         {"Bokført dato", "Bokføringsdato", "Dato", "Date", "Booking date"},
+	"amount":       {"Beløp", "Amount"},
+	"in":           {"Inn på konto", "Inn", "Innskudd"},
+	"out":          {"Ut fra konto", "Ut", "Uttak"},
+	"text":         {"Forklaring", "Beskrivelse", "Tekst", "Text", "Description"},
+	"reference":    {"KID", "Referanse", "Reference"},
+	"counterparty": {"Motpart", "Mottaker", "Betaler", "Navn", "Counterparty"},
+}
+
+// readStatement parses a bank statement file. format is "camt", "csv" or
+// "" to detect it from the file extension.
+func readStatement(path, format string, csvFmt csvFormat) ([]statementLine, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".xml", ".053":
+			format = "camt"
+		case ".csv", ".txt":
+			format = "csv"
+		default:
+			return nil, fmt.Errorf("cannot tell the format of %s; use --format camt or --format csv", path)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var lines []statementLine
+	switch format {
+	case "camt":
+		lines, err = parseCAMT053(f)
+	case "csv":
+		lines, err = parseStatementCSV(f, csvFmt)
+	default:
+		return nil, fmt.Errorf("invalid format %q (valid: camt, csv)", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return lines, nil
+}
+
+// camtDocument holds the parts of an ISO 20022 CAMT.053 statement that are
+// needed for reconciliation. Element names are matched without namespace so
+// that all camt.053 versions are accepted.
+type camtDocument struct {
+	Statements []struct {
+		Entries []camtEntry `xml:"Ntry"`
+	} `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtEntry struct {
+	Amount    camtAmount `xml:"Amt"`
+	Indicator string     `xml:"CdtDbtInd"`
+	Status    camtStatus `xml:"Sts"`
+	BookDate  camtDate   `xml:"BookgDt"`
+	ValueDate camtDate   `xml:"ValDt"`
+	Info      string     `xml:"AddtlNtryInf"`
+	Details   []camtTx   `xml:"NtryDtls>TxDtls"`
+}
+
+type camtTx struct {
+	Amount       *camtAmount `xml:"Amt"`
+	TxAmount     *camtAmount `xml:"AmtDtls>TxAmt>Amt"`
+	Indicator    string      `xml:"CdtDbtInd"`
+	Reference    string      `xml:"RmtInf>Strd>CdtrRefInf>Ref"`
+	Unstructured []string    `xml:"RmtInf>Ustrd"`
+	Debtor       camtParty   `xml:"RltdPties>Dbtr"`
+	Creditor     camtParty   `xml:"RltdPties>Cdtr"`
+	Info         string      `xml:"AddtlTxInf"`
+}
+
+type camtAmount struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// camtStatus holds an entry status, which camt.053.001.08 and later wrap
+// in Cd.
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+// camtParty holds a party name; camt.053.001.08 and later wrap it in Pty.
+type camtParty struct {
+	Name      string `xml:"Nm"`
+	PartyName string `xml:"Pty>Nm"`
+}
+
+func (d camtDate) value() string {
+	if d.Date != "" {
+		return d.Date
+	}
+	if len(d.DateTime) >= 10 {
+		return d.DateTime[:10]
+	}
+	return ""
+}
+
+func (p camtParty) name() string {
+	if p.Name != "" {
+		return p.Name
+	}
+	return p.PartyName
+}
+
+// parseCAMT053 parses the booked entries of a CAMT.053 statement. An entry
+// with several transactions of their own amount (a batch) gives one line
+// per transaction.
+func parseCAMT053(r io.Reader) ([]statementLine, error) {
+	var doc camtDocument
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(charset) {
+		case "iso-8859-1", "latin1", "windows-1252":
+			data, err := io.ReadAll(input)
+			if err != nil {
+				return nil, err
+			}
+			return strings.NewReader(latin1ToUTF8(data)), nil
+		}
+		return nil, fmt.Errorf("unsupported charset %q", charset)
+	}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var lines []statementLine
+	for _, stmt := range doc.Statements {
+		for _, e := range stmt.Entries {
+			status := e.Status.Code
+			if status == "" {
+				status = strings.TrimSpace(e.Status.Value)
+			}
+			if status != "" && status != "BOOK" {
+				continue
+			}
+
+			date := e.BookDate.value()
+			if date == "" {
+				date = e.ValueDate.value()
+			}
+
+			batch := len(e.Details) > 1
+			for _, tx := range e.Details {
+				if tx.Amount == nil && tx.TxAmount == nil {
+					batch = false
+				}
+			}
+
+			if !batch {
+				line, err := camtLine(e.Amount, e.Indicator, date)
+				if err != nil {
+					return nil, err
+				}
+				if len(e.Details) == 1 {
+					camtDetails(&line, e.Details[0])
+				}
+				if line.Text == "" {
+					line.Text = strings.TrimSpace(e.Info)
+				}
+				lines = append(lines, line)
+				continue
+			}
+
+			for _, tx := range e.Details {
+				amt := tx.Amount
+				if amt == nil {
+					amt = tx.TxAmount
+				}
+				indicator := tx.Indicator
+				if indicator == "" {
+					indicator = e.Indicator
+				}
+				line, err := camtLine(*amt, indicator, date)
+				if err != nil {
+					return nil, err
+				}
+				camtDetails(&line, tx)
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines, nil
+}
+
+func camtLine(amt camtAmount, indicator, date string) (statementLine, error) {
+	value, err := parseAmount(amt.Value)
+	if err != nil {
+		return statementLine{}, err
+	}
+	switch indicator {
+	case "CRDT":
+	case "DBIT":
+		value = -value
+	default:
+		return statementLine{}, fmt.Errorf("invalid credit/debit indicator %q", indicator)
+	}
+	if err := validateDate(date); err != nil {
+		return statementLine{}, err
+	}
+	return statementLine{Date: date, Amount: value, Currency: amt.Currency}, nil
+}
+
+func camtDetails(line *statementLine, tx camtTx) {
+	line.Reference = strings.TrimSpace(tx.Reference)
+	// The counterparty of an incoming payment is the debtor, and of an
+	// outgoing payment the creditor.
+	if line.Amount >= 0 {
+		line.Counterparty = tx.Debtor.name()
+	} else {
+		line.Counterparty = tx.Creditor.name()
+	}
+	line.Text = strings.TrimSpace(strings.Join(tx.Unstructured, " "))
+	if line.Text == "" {
+		line.Text = strings.TrimSpace(tx.Info)
+	}
+}
+
+// parseStatementCSV parses a CSV statement with a header row. Files that
+// are not valid UTF-8 are read as Latin-1, which many banks export.
+func parseStatementCSV(r io.Reader, format csvFormat) ([]statementLine, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	text := string(data)
+	if !utf8.Valid(data) {
+		text = latin1ToUTF8(data)
+	}
+
+	cr := csv.NewReader(strings.NewReader(text))
+	cr.Comma = ';'
+	if format.Delimiter != "" {
+		if format.Delimiter == `\t` || format.Delimiter == "tab" {
+			format.Delimiter = "\t"
+		}
+		cr.Comma = []rune(format.Delimiter)[0]
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("the file is empty")
+	}
+
+	header := records[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	col := func(key, given string) (int, error) {
+		if given != "" {
+			if n, err := strconv.Atoi(given); err == nil {
+				if n < 1 || n > len(header) {
+					return -1, fmt.Errorf("%s column %d is out of range (1-%d)", key, n, len(header))
+				}
+				return n - 1, nil
+			}
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(h), given) {
+					return i, nil
+				}
+			}
+			return -1, fmt.Errorf("no %s column named %q in the header", key, given)
+		}
+		for _, name := range csvColumnNames[key] {
+			for i, h := range header {
+				if strings.EqualFold(strings.TrimSpace(h), name) {
+					return i, nil
+				}
+			}
+		}
+		return -1, nil
+	}
+
+	dateCol, err := col("date", format.Date)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := col("amount", format.Amount)
+	if err != nil {
+		return nil, err
+	}
+	inCol, err := col("in", format.In)
+	if err != nil {
+		return nil, err
+	}
+	outCol, err := col("out", format.Out)
+	if err != nil {
+		return nil, err
+	}
+	textCol, err := col("text", format.Text)
+	if err != nil {
+		return nil, err
+	}
+	refCol, err := col("reference", format.Reference)
+	if err != nil {
+		return nil, err
+	}
+	partyCol, err := col("counterparty", format.Counterparty)
+	if err != nil {
+		return nil, err
+	}
+
+	if dateCol < 0 {
+		return nil, fmt.Errorf("no date column found; set it with --csv-date")
+	}
+	if amountCol < 0 && inCol < 0 && outCol < 0 {
+		return nil, fmt.Errorf("no amount column found; set it with --csv-amount or --csv-in/--csv-out")
+	}
+
+	dateFormat := format.DateFormat
+	if dateFormat == "" {
+		dateFormat = "02.01.2006"
+	}
+
+	field := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var lines []statementLine
+	for n, record := range records[1:] {
+		rawDate := field(record, dateCol)
+		if rawDate == "" {
+			continue
+		}
+		date, err := parseStatementDate(rawDate, dateFormat)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", n+2, err)
+		}
+
+		var value int64
+		if amountCol >= 0 {
+			value, err = parseAmount(field(record, amountCol))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", n+2, err)
+			}
+		} else {
+			if s := field(record, inCol); s != "" {
+				value, err = parseAmount(s)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", n+2, err)
+				}
+			}
+			if s := field(record, outCol); s != "" {
+				out, err := parseAmount(s)
+				if err != nil {
+					return nil, fmt.Errorf("row %d: %w", n+2, err)
+				}
+				// Some banks show money out as negative, others as positive.
+				if out > 0 {
+					out = -out
+				}
+				value += out
+			}
+		}
+
+		lines = append(lines, statementLine{
+			Date:         date,
+			Amount:       value,
+			Reference:    field(record, refCol),
+			Counterparty: field(record, partyCol),
+			Text:         field(record, textCol),
+		})
+	}
+	return lines, nil
+}
+
+// latin1ToUTF8 converts ISO 8859-1 text to UTF-8.
+func latin1ToUTF8(data []byte) string {
+	runes := make([]rune, len(data))
+	for i, b := range data {
+		runes[i] = rune(b)
+	}
+	return string(runes)
+}
+
+// parseStatementDate parses a statement date in layout, or YYYY-MM-DD.
+func parseStatementDate(s, layout string) (string, error) {
+	for _, l := range []string{layout, "2006-01-02"} {
+		if t, err := time.Parse(l, s); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q (expected format %s)", s, layout)
+}
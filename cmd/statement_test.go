@@ -0,0 +1,195 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testCAMT053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Ntry>
+        <Amt Ccy="NOK">1250.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-03-04</Dt></BookgDt>
+        <NtryDtls><TxDtls>
+          <RltdPties><Dbtr><Nm>Acme AS</Nm></Dbtr></RltdPties>
+          <RmtInf><Strd><CdtrRefInf><Ref>0000012345678</Ref></CdtrRefInf></Strd></RmtInf>
+        </TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="NOK">99.50</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>PDNG</Sts>
+        <BookgDt><Dt>2024-03-05</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="NOK">300.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-03-06T10:00:00</DtTm></BookgDt>
+        <NtryDtls>
+          <TxDtls>
+            <Amt Ccy="NOK">100.00</Amt>
+            <RltdPties><Cdtr><Pty><Nm>Kontor AS</Nm></Pty></Cdtr></RltdPties>
+            <RmtInf><Ustrd>Faktura</Ustrd><Ustrd>881</Ustrd></RmtInf>
+          </TxDtls>
+          <TxDtls>
+            <AmtDtls><TxAmt><Amt Ccy="NOK">200.00</Amt></TxAmt></AmtDtls>
+            <AddtlTxInf>Husleie</AddtlTxInf>
+          </TxDtls>
+        </NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="EUR">10.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <ValDt><Dt>2024-03-07</Dt></ValDt>
+        <AddtlNtryInf>Renter</AddtlNtryInf>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+func TestParseCAMT053(t *testing.T) {
+	got, err := parseCAMT053(strings.NewReader(testCAMT053))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []statementLine{
+		{Date: "2024-03-04", Amount: 125000, Currency: "NOK", Reference: "0000012345678", Counterparty: "Acme AS"},
+		{Date: "2024-03-06", Amount: -10000, Currency: "NOK", Counterparty: "Kontor AS", Text: "Faktura 881"},
+		{Date: "2024-03-06", Amount: -20000, Currency: "NOK", Text: "Husleie"},
+		{Date: "2024-03-07", Amount: 1000, Currency: "EUR", Text: "Renter"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseCAMT053 =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseCAMT053Errors(t *testing.T) {
+	tests := []struct {
+		name, xml, wantErr string
+	}{
+		{"not xml", "hello", "EOF"},
+		{"bad indicator", `<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy="NOK">1.00</Amt><CdtDbtInd>X</CdtDbtInd><BookgDt><Dt>2024-01-01</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`, "credit/debit indicator"},
+		{"no date", `<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy="NOK">1.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Ntry></Stmt></BkToCstmrStmt></Document>`, "invalid date"},
+		{"bad amount", `<Document><BkToCstmrStmt><Stmt><Ntry><Amt Ccy="NOK">1.0.0</Amt><CdtDbtInd>CRDT</CdtDbtInd><BookgDt><Dt>2024-01-01</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`, "amount"},
+	}
+	for _, tt := range tests {
+		_, err := parseCAMT053(strings.NewReader(tt.xml))
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestParseStatementCSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		csv     string
+		format  csvFormat
+		want    []statementLine
+		wantErr string
+	}{
+		{
+			name: "signed amount with Norwegian headers",
+			csv: "\ufeffDato;Forklaring;KID;Beløp\n" +
+				"04.03.2024;Innbetaling Acme AS;0000012345678;1 250,00\n" +
+				";;;\n" +
+				"05.03.2024;Kontor AS;;-99,50\n",
+			want: []statementLine{
+				{Date: "2024-03-04", Amount: 125000, Reference: "0000012345678", Text: "Innbetaling Acme AS"},
+				{Date: "2024-03-05", Amount: -9950, Text: "Kontor AS"},
+			},
+		},
+		{
+			name: "in and out columns",
+			csv: "Bokført dato;Motpart;Inn på konto;Ut fra konto\n" +
+				"2024-03-04;Acme AS;100,00;\n" +
+				"2024-03-05;Kontor AS;;50,00\n" +
+				"2024-03-06;Bank;;-25,00\n",
+			want: []statementLine{
+				{Date: "2024-03-04", Amount: 10000, Counterparty: "Acme AS"},
+				{Date: "2024-03-05", Amount: -5000, Counterparty: "Kontor AS"},
+				{Date: "2024-03-06", Amount: -2500, Counterparty: "Bank"},
+			},
+		},
+		{
+			name:   "configured columns and layout",
+			csv:    "Booked,Sum,Info\n2024/03/04,12.50,Coffee\n",
+			format: csvFormat{Delimiter: ",", DateFormat: "2006/01/02", Date: "booked", Amount: "2", Text: "Info"},
+			want: []statementLine{
+				{Date: "2024-03-04", Amount: 1250, Text: "Coffee"},
+			},
+		},
+		{
+			name:   "tab delimiter",
+			csv:    "Dato\tBeløp\n01.02.2024\t5\n",
+			format: csvFormat{Delimiter: `\t`},
+			want:   []statementLine{{Date: "2024-02-01", Amount: 500}},
+		},
+		{
+			name:    "no date column",
+			csv:     "When;Beløp\n01.02.2024;5\n",
+			wantErr: "no date column",
+		},
+		{
+			name:    "no amount column",
+			csv:     "Dato;Tekst\n01.02.2024;x\n",
+			wantErr: "no amount column",
+		},
+		{
+			name:    "column out of range",
+			csv:     "Dato;Beløp\n01.02.2024;5\n",
+			format:  csvFormat{Amount: "3"},
+			wantErr: "out of range",
+		},
+		{
+			name:    "bad date",
+			csv:     "Dato;Beløp\n2024.02.01;5\n",
+			wantErr: "row 2: invalid date",
+		},
+		{
+			name:    "bad amount",
+			csv:     "Dato;Beløp\n01.02.2024;five\n",
+			wantErr: "row 2:",
+		},
+		{
+			name:    "empty",
+			csv:     "",
+			wantErr: "empty",
+		},
+	}
+	for _, tt := range tests {
+		got, err := parseStatementCSV(strings.NewReader(tt.csv), tt.format)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: error: %v", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got\n%+v\nwant\n%+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseStatementCSVLatin1(t *testing.T) {
+	// "Beløp" with ø as the single Latin-1 byte 0xF8.
+	data := "Dato;Bel\xf8p;Forklaring\n01.02.2024;5;K\xe5re\n"
+	got, err := parseStatementCSV(strings.NewReader(data), csvFormat{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []statementLine{{Date: "2024-02-01", Amount: 500, Text: "Kåre"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
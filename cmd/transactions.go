@@ -2,25 +2,254 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"strings"
 
+	"github.com/jakoblind/fiken-cli/api"
+	"github.com/jakoblind/fiken-cli/output"
 	"github.com/spf13/cobra"
 )
 
+var (
+	transactionsFrom        string
+	transactionsTo          string
+	transactionsType        string
+	transactionsLimit       int
+	transactionsPageSize    int
+	transactionsDescription string
+)
+
 var transactionsCmd = &cobra.Command{
 	Use:   "transactions",
 	Short: "Manage transactions",
+	Long: `List, inspect and reverse transactions.
+
+A transaction holds the postings made by a sale, purchase, payment or
+journal voucher.`,
 }
 
 var transactionsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List transactions",
+	Long: `List transactions.
+
+The --from, --to and --type filters are applied locally. --from and --to
+match the voucher date of the transaction's entries.`,
+	Example: `  fiken transactions list --from 2024-01-01 --to 2024-01-31
+  fiken transactions list --type generalJournal --limit 20`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		fmt.Println("Not yet implemented.")
+		for _, date := range []string{transactionsFrom, transactionsTo} {
+			if date == "" {
+				continue
+			}
+			if err := validateDate(date); err != nil {
+				return err
+			}
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		endpoint := fmt.Sprintf(api.EndpointTransactions, slug)
+
+		// The filters are applied locally, so the limit is applied afterwards.
+		localFilter := transactionsFrom != "" || transactionsTo != "" || transactionsType != ""
+		limit := transactionsLimit
+		if localFilter {
+			limit = 0
+		}
+		transactions, err := fetchPages[api.Transaction](client, endpoint, url.Values{}, transactionsPageSize, limit)
+		if err != nil {
+			return fmt.Errorf("fetching transactions: %w", err)
+		}
+
+		if localFilter {
+			transactions = filterItems(transactions, func(t api.Transaction) bool {
+				if transactionsType != "" && !strings.EqualFold(t.Type, transactionsType) {
+					return false
+				}
+				date := transactionDate(t)
+				if transactionsFrom != "" && date < transactionsFrom {
+					return false
+				}
+				return transactionsTo == "" || date <= transactionsTo
+			}, transactionsLimit)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(transactions)
+		}
+
+		if len(transactions) == 0 {
+			output.PrintInfo("No transactions found.")
+			return nil
+		}
+
+		table := output.NewTable("ID", "DATE", "TYPE", "DESCRIPTION", "AMOUNT", "ENTRIES")
+		for _, t := range transactions {
+			table.AddRow(
+				fmt.Sprintf("%d", t.TransactionId),
+				transactionDate(t),
+				t.Type,
+				t.Description,
+				output.FormatAmount(transactionAmount(t)),
+				fmt.Sprintf("%d", len(t.Entries)),
+			)
+		}
+		table.Print()
+
+		fmt.Printf("\n%d transactions\n", len(transactions))
 		return nil
 	},
 }
 
+var transactionsGetCmd = &cobra.Command{
+	Use:   "get <transactionId>",
+	Short: "Show a transaction with all its postings",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		transactionID, err := parseID(args[0], "transaction")
+		if err != nil {
+			return err
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		var transaction api.Transaction
+		_, err = client.Get(fmt.Sprintf(api.EndpointTransaction, slug, transactionID), &transaction)
+		if err != nil {
+			return fmt.Errorf("fetching transaction: %w", err)
+		}
+
+		if jsonOutput {
+			return output.PrintJSON(transaction)
+		}
+
+		d := output.NewDetails()
+		d.Add("ID", fmt.Sprintf("%d", transaction.TransactionId))
+		d.Add("Date", transactionDate(transaction))
+		d.Add("Type", transaction.Type)
+		d.Add("Description", transaction.Description)
+		d.Add("Amount", output.FormatAmount(transactionAmount(transaction)))
+		d.Add("Created", transaction.CreatedDate)
+		d.Add("Last modified", transaction.LastModifiedDate)
+		if transaction.Deleted {
+			d.Add("Deleted", "Yes")
+		}
+		d.Print()
+
+		var attachments []api.Attachment
+		for _, e := range transaction.Entries {
+			fmt.Println()
+			title := fmt.Sprintf("Entry %d", e.JournalEntryId)
+			if e.JournalEntryNumber != 0 {
+				title += fmt.Sprintf(" (voucher %d)", e.JournalEntryNumber)
+			}
+			fmt.Printf("%s  %s  %s\n", title, e.Date, e.Description)
+			printJournalLines(e.Lines)
+			attachments = append(attachments, e.Attachments...)
+		}
+
+		if len(attachments) > 0 {
+			fmt.Println()
+			printAttachments(attachments)
+		}
+		return nil
+	},
+}
+
+var transactionsDeleteCmd = &cobra.Command{
+	Use:   "delete <transactionId>",
+	Short: "Delete (reverse) a transaction",
+	Long: `Delete a transaction. Fiken keeps the original postings and reverses
+them with an offsetting transaction. A description of why the transaction
+is deleted is required.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		transactionID, err := parseID(args[0], "transaction")
+		if err != nil {
+			return err
+		}
+		if transactionsDescription == "" {
+			return fmt.Errorf("--description is required")
+		}
+
+		client, err := getClient()
+		if err != nil {
+			return err
+		}
+
+		slug, err := resolveCompany(client)
+		if err != nil {
+			return err
+		}
+
+		if !confirm(fmt.Sprintf("Reverse transaction %d?", transactionID)) {
+			output.PrintInfo("Aborted.")
+			return nil
+		}
+
+		params := url.Values{"description": {transactionsDescription}}
+		endpoint := fmt.Sprintf(api.EndpointTransactionDelete, slug, transactionID) + "?" + params.Encode()
+		if err := client.Patch(endpoint, nil, nil); err != nil {
+			return fmt.Errorf("deleting transaction: %w", err)
+		}
+
+		output.PrintSuccess(fmt.Sprintf("Transaction %d reversed", transactionID))
+		return nil
+	},
+}
+
+// transactionDate returns the date of a transaction, falling back to the
+// voucher date of its first entry.
+func transactionDate(t api.Transaction) string {
+	if t.Date != "" {
+		return t.Date
+	}
+	for _, e := range t.Entries {
+		if e.Date != "" {
+			return e.Date
+		}
+	}
+	return ""
+}
+
+// transactionAmount returns the total debit amount of a transaction's entries.
+func transactionAmount(t api.Transaction) int64 {
+	var total int64
+	for _, e := range t.Entries {
+		debit, _ := journalTotals(e.Lines)
+		total += debit
+	}
+	return total
+}
+
 func init() {
+	transactionsListCmd.Flags().StringVar(&transactionsFrom, "from", "", "Date from (YYYY-MM-DD)")
+	transactionsListCmd.Flags().StringVar(&transactionsTo, "to", "", "Date to (YYYY-MM-DD)")
+	transactionsListCmd.Flags().StringVar(&transactionsType, "type", "", "Filter by transaction type")
+	transactionsListCmd.Flags().IntVar(&transactionsLimit, "limit", 0, "Maximum number of transactions to show (0 = all)")
+	transactionsListCmd.Flags().IntVar(&transactionsPageSize, "page-size", api.MaxPageSize, "Number of transactions to fetch per request")
+
+	transactionsDeleteCmd.Flags().StringVar(&transactionsDescription, "description", "", "Reason for deleting the transaction (required)")
+
 	transactionsCmd.AddCommand(transactionsListCmd)
+	transactionsCmd.AddCommand(transactionsGetCmd)
+	transactionsCmd.AddCommand(transactionsDeleteCmd)
 	rootCmd.AddCommand(transactionsCmd)
 }
@@ -51,6 +51,33 @@ func (t *Table) Print() {
 	w.Flush()
 }
 
+// Details helps build and print a key/value detail view of a single resource.
+type Details struct {
+	rows [][2]string
+}
+
+// NewDetails creates a new, empty detail view.
+func NewDetails() *Details {
+	return &Details{}
+}
+
+// Add adds a field to the detail view. Empty values are skipped.
+func (d *Details) Add(key, value string) {
+	if value == "" {
+		return
+	}
+	d.rows = append(d.rows, [2]string{key, value})
+}
+
+// Print outputs the detail view to stdout.
+func (d *Details) Print() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, row := range d.rows {
+		fmt.Fprintf(w, "%s:\t%s\n", row[0], row[1])
+	}
+	w.Flush()
+}
+
 // FormatAmount converts cents to a human-readable amount string (e.g., 100000 -> "1 000,00").
 func FormatAmount(cents int64) string {
 	negative := cents < 0
@@ -100,3 +127,9 @@ func PrintError(msg string) {
 func PrintInfo(msg string) {
 	fmt.Printf("ℹ %s\n", msg)
 }
+
+// PrintWarning prints a warning message. It goes to stderr so that it does
+// not mix with JSON output.
+func PrintWarning(msg string) {
+	fmt.Fprintf(os.Stderr, "! %s\n", msg)
+}